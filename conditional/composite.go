@@ -6,6 +6,7 @@ import "reflect"
 type CompositeCondition struct {
 	Condition
 	operator      CompositeOperator
+	unknownPolicy UnknownPolicy
 	subconditions []Condition
 	stop          chan struct{}
 }
@@ -34,6 +35,18 @@ var (
 // NewCompositeCondition instantiates a new CompositeCondition that uses the
 // specified operator and has the specified sub-conditions.
 func NewCompositeCondition(operator CompositeOperator, conditions ...Condition) *CompositeCondition {
+	return NewStateCompositeCondition(operator, UnknownHold, conditions...)
+}
+
+// NewStateCompositeCondition instantiates a new CompositeCondition that uses
+// the specified operator and has the specified sub-conditions.
+//
+// Unknown sub-conditions are treated according to the specified policy:
+// UnknownHold uses their satisfied state, as defined by their own policy,
+// UnknownFalse and UnknownTrue replace their state with the specified one, and
+// UnknownPropagate makes the composite condition unknown unless its result
+// does not depend on the state of the unknown sub-conditions.
+func NewStateCompositeCondition(operator CompositeOperator, policy UnknownPolicy, conditions ...Condition) *CompositeCondition {
 	if len(conditions) == 0 {
 		panic("cannot instantiate a composite condition without at least one sub-condition")
 	}
@@ -41,6 +54,7 @@ func NewCompositeCondition(operator CompositeOperator, conditions ...Condition)
 	condition := &CompositeCondition{
 		Condition:     NewManualCondition(false),
 		operator:      operator,
+		unknownPolicy: policy,
 		subconditions: conditions,
 		stop:          make(chan struct{}),
 	}
@@ -72,20 +86,27 @@ func (c *CompositeCondition) Close() error {
 	return c.Condition.Close()
 }
 
+// State returns the current tri-state of the condition.
+func (c *CompositeCondition) State() State {
+	return StateOf(c.Condition)
+}
+
 func (c *CompositeCondition) watchConditions(ready chan struct{}) {
 	for {
 		count := len(c.subconditions)
 		values := make([]bool, count, count)
+		states := make([]State, count, count)
 		cases := make([]reflect.SelectCase, count+1)
 
 		for i, condition := range c.subconditions {
 			value, channel := condition.GetAndWaitChange()
 			values[i] = value
+			states[i] = StateOf(condition)
 			cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(channel)}
 		}
 
 		cases[count] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.stop)}
-		c.Condition.(*ManualCondition).Set(c.operator.Reduce(values))
+		c.Condition.(*ManualCondition).SetState(c.reduce(values, states))
 		close(ready)
 
 		for {
@@ -98,12 +119,59 @@ func (c *CompositeCondition) watchConditions(ready chan struct{}) {
 			condition := c.subconditions[chosen]
 			value, channel := condition.GetAndWaitChange()
 			values[chosen] = value
+			states[chosen] = StateOf(condition)
 			cases[chosen] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(channel)}
-			c.Condition.(*ManualCondition).Set(c.operator.Reduce(values))
+			c.Condition.(*ManualCondition).SetState(c.reduce(values, states))
 		}
 	}
 }
 
+func (c *CompositeCondition) reduce(values []bool, states []State) State {
+	var unknowns []int
+
+	for i, state := range states {
+		if !state.Known() {
+			unknowns = append(unknowns, i)
+		}
+	}
+
+	if len(unknowns) == 0 || c.unknownPolicy == UnknownHold {
+		return StateFromBool(c.operator.Reduce(values))
+	}
+
+	substituted := make([]bool, len(values))
+	copy(substituted, values)
+
+	switch c.unknownPolicy {
+	case UnknownFalse, UnknownTrue:
+		for _, i := range unknowns {
+			substituted[i] = c.unknownPolicy == UnknownTrue
+		}
+
+		return StateFromBool(c.operator.Reduce(substituted))
+	}
+
+	// The result is only known if it is the same for all the possible states
+	// of the unknown sub-conditions.
+	var result bool
+
+	for combination := 0; combination < 1<<uint(len(unknowns)); combination++ {
+		for bit, i := range unknowns {
+			substituted[i] = combination&(1<<uint(bit)) != 0
+		}
+
+		value := c.operator.Reduce(substituted)
+
+		if combination == 0 {
+			result = value
+		} else if value != result {
+			return StateUnknown
+		}
+	}
+
+	return StateFromBool(result)
+}
+
 type operatorAnd struct{}
 
 func (o operatorAnd) Reduce(values []bool) bool {
@@ -116,3 +116,50 @@ func TestOperatorXorString(t *testing.T) {
 		t.Errorf("expected: %s, got: %s", expected, value)
 	}
 }
+
+func TestCompositeConditionUnknownPolicies(t *testing.T) {
+	testCases := []struct {
+		Operator CompositeOperator
+		Policy   UnknownPolicy
+		Known    bool
+		Expected State
+	}{
+		{OperatorAnd, UnknownHold, true, StateTrue},
+		{OperatorAnd, UnknownFalse, true, StateFalse},
+		{OperatorAnd, UnknownTrue, true, StateTrue},
+		{OperatorAnd, UnknownPropagate, true, StateUnknown},
+		{OperatorAnd, UnknownPropagate, false, StateFalse},
+		{OperatorOr, UnknownPropagate, true, StateTrue},
+		{OperatorOr, UnknownPropagate, false, StateUnknown},
+		{OperatorXor, UnknownPropagate, true, StateUnknown},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Operator.String()+"/"+testCase.Policy.String(), func(t *testing.T) {
+			known := NewManualCondition(testCase.Known)
+			unknown := NewManualCondition(true)
+			unknown.SetUnknown()
+
+			condition := NewStateCompositeCondition(testCase.Operator, testCase.Policy, known, unknown)
+			defer condition.Close()
+
+			if state := condition.State(); state != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, state)
+			}
+
+			ch := make(chan State, 10)
+			unregister := condition.Register(NewStateChannelObserver(ch))
+			defer unregister()
+			<-ch
+
+			unknown.Set(false)
+			expected := StateFromBool(testCase.Operator.Reduce([]bool{testCase.Known, false}))
+
+			if state := condition.State(); state != expected {
+				if state = <-ch; state != expected {
+					t.Errorf("expected %s, got %s", expected, state)
+				}
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@
 // of complex condition compositions.
 package conditional
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/intelux/gotomatic/executor"
+)
 
 // ErrConditionClosed is the error returned when a wait on a condition is
 // interrupted because the channel was closed.
@@ -44,3 +48,65 @@ type Condition interface {
 	// current state until the returned cancel function is called.
 	Register(ConditionStateObserver) func()
 }
+
+// A StateCondition is a Condition whose satisfied state may also be unknown.
+//
+// While unknown, the satisfied state of the condition is defined by its
+// UnknownPolicy. Observers that implement StateObserver are notified of all
+// tri-state changes, including the ones that do not change the satisfied
+// state.
+type StateCondition interface {
+	Condition
+
+	// State returns the current tri-state of the condition.
+	State() State
+}
+
+// StateOf returns the current tri-state of the specified condition.
+//
+// Conditions that do not implement StateCondition are never unknown.
+func StateOf(condition Condition) State {
+	if condition, ok := condition.(StateCondition); ok {
+		return condition.State()
+	}
+
+	satisfied, _ := condition.GetAndWaitChange()
+
+	return StateFromBool(satisfied)
+}
+
+// A ResultCondition is a Condition driven by an executor, that can report the
+// result of its last executor call.
+type ResultCondition interface {
+	Condition
+
+	// LastResult returns the result of the last executor call.
+	LastResult() executor.Result
+}
+
+// A conditionWrapper is a Condition that wraps another one.
+type conditionWrapper interface {
+	unwrap() Condition
+}
+
+// LastResultOf returns the result of the last executor call of the specified
+// condition, looking through the conditions it wraps.
+//
+// If the condition is not driven by an executor, false is returned.
+func LastResultOf(condition Condition) (executor.Result, bool) {
+	for condition != nil {
+		if condition, ok := condition.(ResultCondition); ok {
+			return condition.LastResult(), true
+		}
+
+		wrapper, ok := condition.(conditionWrapper)
+
+		if !ok {
+			break
+		}
+
+		condition = wrapper.unwrap()
+	}
+
+	return executor.Result{}, false
+}
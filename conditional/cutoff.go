@@ -2,6 +2,7 @@ package conditional
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/intelux/gotomatic/executor"
@@ -13,10 +14,66 @@ type cutOffCondition struct {
 	downThreshold uint
 	period        time.Duration
 	executor      executor.Executor
-	done          chan struct{}
+	staleTimeout  time.Duration
+	unknownPolicy UnknownPolicy
+	scheduler     *executor.Scheduler
+	timeout       time.Duration
+	cancel        func()
 	counter       uint
 	lastState     bool
+	lastKnown     time.Time
+	unknown       bool
 	locked        bool
+	resultLock    sync.Mutex
+	lastResult    executor.Result
+}
+
+// CutOffConditionOption represents an option for a cut-off condition.
+type CutOffConditionOption interface {
+	apply(condition *cutOffCondition)
+}
+
+// StaleTimeoutOption makes a cut-off condition stale-aware.
+//
+// Failed executor calls are then ignored, instead of being considered as
+// false, and the condition becomes unknown when the executor has not
+// succeeded for the specified timeout.
+type StaleTimeoutOption struct {
+	Timeout time.Duration
+}
+
+func (o StaleTimeoutOption) apply(condition *cutOffCondition) {
+	condition.staleTimeout = o.Timeout
+}
+
+// UnknownPolicyOption defines how a cut-off condition is considered while
+// unknown.
+type UnknownPolicyOption struct {
+	Policy UnknownPolicy
+}
+
+func (o UnknownPolicyOption) apply(condition *cutOffCondition) {
+	condition.unknownPolicy = o.Policy
+}
+
+// ProbeSchedulerOption defines the scheduler that calls the executor of a
+// cut-off condition.
+type ProbeSchedulerOption struct {
+	Scheduler *executor.Scheduler
+}
+
+func (o ProbeSchedulerOption) apply(condition *cutOffCondition) {
+	condition.scheduler = o.Scheduler
+}
+
+// ProbeTimeoutOption defines how long each executor call of a cut-off
+// condition can last.
+type ProbeTimeoutOption struct {
+	Timeout time.Duration
+}
+
+func (o ProbeTimeoutOption) apply(condition *cutOffCondition) {
+	condition.timeout = o.Timeout
 }
 
 // NewCutOffCondition creates a new cut-off condition.
@@ -30,42 +87,107 @@ type cutOffCondition struct {
 // Any change of the executor return value resets both counters.
 //
 // The inital status of the condition is the return value of the executor.
-func NewCutOffCondition(upThreshold uint, downThreshold uint, period time.Duration, executor executor.Executor) Condition {
-	ctx, cancel := context.WithTimeout(context.Background(), period)
-	state := executor(ctx)
-	cancel()
-
+//
+// Unless a StaleTimeoutOption is specified, failed executor calls are
+// considered as false. Otherwise, the condition becomes unknown when the
+// executor fails for long enough, and gets its state from the first
+// successful executor call afterwards.
+//
+// The executor is called by executor.DefaultScheduler, unless a
+// ProbeSchedulerOption is specified, with a timeout of one period, unless a
+// ProbeTimeoutOption is specified.
+func NewCutOffCondition(upThreshold uint, downThreshold uint, period time.Duration, executor executor.Executor, options ...CutOffConditionOption) Condition {
 	condition := &cutOffCondition{
-		Condition:     NewManualCondition(state),
 		upThreshold:   upThreshold,
 		downThreshold: downThreshold,
 		period:        period,
 		executor:      executor,
-		done:          make(chan struct{}),
-		lastState:     state,
 		locked:        true,
 	}
 
-	go condition.run(condition.done)
+	for _, option := range options {
+		option.apply(condition)
+	}
+
+	if condition.timeout <= 0 {
+		condition.timeout = period
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), condition.timeout)
+	result := executor(ctx)
+	cancel()
+
+	condition.lastResult = result
+	manual := NewManualCondition(result.OK)
+	manual.SetUnknownPolicy(condition.unknownPolicy)
+	condition.Condition = manual
+	condition.lastKnown = time.Now()
+
+	if result.Known() || condition.staleTimeout <= 0 {
+		condition.lastState = result.OK
+	} else {
+		condition.unknown = true
+		manual.SetUnknown()
+	}
+
+	condition.schedule()
 
 	return condition
 }
 
-func (c *cutOffCondition) run(done <-chan struct{}) {
-	ticker := time.NewTicker(c.period)
+func (c *cutOffCondition) schedule() {
+	if c.scheduler == nil {
+		c.scheduler = executor.DefaultScheduler
+	}
+
+	c.cancel = c.scheduler.Schedule(c.period, c.timeout, c.executor, func(result executor.Result) {
+		c.probe(result, time.Now())
+	})
+}
+
+// State returns the current tri-state of the condition.
+func (c *cutOffCondition) State() State {
+	return StateOf(c.Condition)
+}
 
-	for {
-		select {
-		case <-done:
-			ticker.Stop()
-			return
-		case <-ticker.C:
-			ctx, cancel := context.WithTimeout(context.Background(), c.period)
-			state := c.executor(ctx)
-			cancel()
-			c.tick(state)
+// LastResult returns the result of the last executor call.
+func (c *cutOffCondition) LastResult() executor.Result {
+	c.resultLock.Lock()
+	defer c.resultLock.Unlock()
+
+	return c.lastResult
+}
+
+func (c *cutOffCondition) probe(result executor.Result, now time.Time) {
+	c.resultLock.Lock()
+	c.lastResult = result
+	c.resultLock.Unlock()
+
+	if c.staleTimeout <= 0 {
+		c.tick(result.OK)
+		return
+	}
+
+	if !result.Known() {
+		if !c.unknown && now.Sub(c.lastKnown) >= c.staleTimeout {
+			c.unknown = true
+			c.Condition.(*ManualCondition).SetUnknown()
 		}
+
+		return
+	}
+
+	c.lastKnown = now
+
+	if c.unknown {
+		c.unknown = false
+		c.lastState = result.OK
+		c.locked = true
+		c.Condition.(*ManualCondition).Set(result.OK)
+		return
 	}
+
+	c.tick(result.OK)
 }
 
 func (c *cutOffCondition) tick(state bool) {
@@ -110,7 +232,7 @@ func (c *cutOffCondition) reset(state bool) {
 }
 
 func (c *cutOffCondition) Close() error {
-	close(c.done)
+	c.cancel()
 
 	return c.Condition.Close()
 }
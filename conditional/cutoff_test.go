@@ -2,8 +2,11 @@ package conditional
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/intelux/gotomatic/executor"
 )
 
 func TestCutOffConditionZeroThresholds(t *testing.T) {
@@ -11,7 +14,7 @@ func TestCutOffConditionZeroThresholds(t *testing.T) {
 	defer close(ch)
 	ch <- true
 
-	condition := NewCutOffCondition(0, 0, time.Millisecond, func(context.Context) bool { return <-ch })
+	condition := NewCutOffCondition(0, 0, time.Millisecond, executor.Bool(func(context.Context) bool { return <-ch }))
 	defer condition.Close()
 
 	assertConditionState(t, condition, true, "initialization to true")
@@ -24,7 +27,7 @@ func TestCutOffConditionPositiveThresholds(t *testing.T) {
 	defer close(ch)
 	ch <- false
 
-	condition := NewCutOffCondition(2, 3, time.Millisecond, func(context.Context) bool { return <-ch })
+	condition := NewCutOffCondition(2, 3, time.Millisecond, executor.Bool(func(context.Context) bool { return <-ch }))
 	defer condition.Close()
 
 	assertConditionState(t, condition, false, "initialization to false")
@@ -39,3 +42,92 @@ func TestCutOffConditionPositiveThresholds(t *testing.T) {
 	ch <- false
 	assertConditionChanged(t, condition, true, "callback returns false", func() { ch <- false })
 }
+
+func TestCutOffConditionFailureIsFalse(t *testing.T) {
+	ch := make(chan executor.Result, 1)
+	defer close(ch)
+	ch <- executor.Result{OK: true}
+
+	condition := NewCutOffCondition(0, 0, time.Millisecond, func(context.Context) executor.Result { return <-ch })
+	defer condition.Close()
+
+	assertConditionState(t, condition, true, "initialization to true")
+	assertConditionChanged(t, condition, true, "callback fails", func() { ch <- executor.Result{Err: errors.New("fail")} })
+
+	if state := StateOf(condition); state != StateFalse {
+		t.Errorf("expected %s, got %s", StateFalse, state)
+	}
+}
+
+func TestCutOffConditionStaleTimeout(t *testing.T) {
+	ch := make(chan executor.Result, 1)
+	defer close(ch)
+	ch <- executor.Result{OK: true}
+
+	condition := NewCutOffCondition(0, 0, time.Hour, func(context.Context) executor.Result { return <-ch }, StaleTimeoutOption{Timeout: time.Minute}).(*cutOffCondition)
+	defer condition.Close()
+
+	now := time.Now()
+	failure := executor.Result{Err: errors.New("fail")}
+
+	condition.probe(failure, now.Add(30*time.Second))
+
+	if state := condition.State(); state != StateTrue {
+		t.Errorf("expected %s after a short failure, got %s", StateTrue, state)
+	}
+
+	condition.probe(failure, now.Add(time.Minute))
+
+	if state := condition.State(); state != StateUnknown {
+		t.Errorf("expected %s after a long failure, got %s", StateUnknown, state)
+	}
+
+	assertConditionState(t, condition, true, "holding the last known state")
+
+	condition.probe(executor.Result{OK: false}, now.Add(2*time.Minute))
+
+	if state := condition.State(); state != StateFalse {
+		t.Errorf("expected %s after a success, got %s", StateFalse, state)
+	}
+}
+
+func TestCutOffConditionStaleInitialization(t *testing.T) {
+	failing := func(context.Context) executor.Result { return executor.Result{Err: errors.New("fail")} }
+	condition := NewCutOffCondition(0, 0, time.Hour, failing, StaleTimeoutOption{Timeout: time.Minute}, UnknownPolicyOption{Policy: UnknownTrue})
+	defer condition.Close()
+
+	if state := StateOf(condition); state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+
+	assertConditionState(t, condition, true, "initialization to unknown")
+}
+
+func TestCutOffConditionLastResult(t *testing.T) {
+	ch := make(chan executor.Result, 1)
+	defer close(ch)
+	ch <- executor.Result{OK: true, ExitCode: 0}
+
+	condition := NewCutOffCondition(0, 0, time.Hour, func(context.Context) executor.Result { return <-ch })
+	defer condition.Close()
+
+	result, ok := LastResultOf(Dereference(Inverse(condition)))
+
+	if !ok {
+		t.Fatal("expected a result")
+	}
+
+	if !result.OK {
+		t.Errorf("expected the initial result, got %s", result)
+	}
+
+	condition.(*cutOffCondition).probe(executor.Result{OK: false, ExitCode: 2}, time.Now())
+
+	if result, _ = LastResultOf(condition); result.ExitCode != 2 {
+		t.Errorf("expected the last result, got %s", result)
+	}
+
+	if _, ok = LastResultOf(Dereference(NewManualCondition(false))); ok {
+		t.Error("expected no result")
+	}
+}
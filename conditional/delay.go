@@ -12,16 +12,23 @@ type delayedCondition struct {
 // Delay returns a Condition whose state changes are reflected if they change
 // at least for the specified duration. The initial state of the passed-in
 // condition is copied without delay.
+//
+// Transitions to and from the unknown state are delayed as well. While unknown,
+// the satisfied state is the one resolved by the passed-in condition.
 func Delay(condition Condition, delay time.Duration) Condition {
-	state, channel := condition.GetAndWaitChange()
+	satisfied, channel := condition.GetAndWaitChange()
+	state := StateOf(condition)
+	manual := NewManualCondition(satisfied)
+	manual.setState(satisfied, !state.Known())
+
 	c := &delayedCondition{
-		Condition:    NewManualCondition(state),
+		Condition:    manual,
 		Delay:        delay,
 		subcondition: condition,
 		done:         make(chan struct{}),
 	}
 
-	go c.waitChange(state, channel)
+	go c.waitChange(satisfied, state, channel)
 
 	return c
 }
@@ -42,6 +49,11 @@ func (condition *delayedCondition) Close() error {
 	return condition.Condition.Close()
 }
 
+// State returns the current tri-state of the condition.
+func (condition *delayedCondition) State() State {
+	return StateOf(condition.Condition)
+}
+
 type timer interface {
 	Wait() <-chan time.Time
 	Stop()
@@ -71,7 +83,7 @@ func (t foreverTimer) Stop() {
 	close(t.channel)
 }
 
-func (condition delayedCondition) waitChange(state bool, channel <-chan error) {
+func (condition delayedCondition) waitChange(satisfied bool, state State, channel <-chan error) {
 	var timer timer = foreverTimer{
 		channel: make(chan time.Time),
 	}
@@ -84,12 +96,13 @@ func (condition delayedCondition) waitChange(state bool, channel <-chan error) {
 			return
 		case <-channel:
 			// The underlying condition changed, let's rewait and start a timer.
-			state, channel = condition.subcondition.GetAndWaitChange()
+			satisfied, channel = condition.subcondition.GetAndWaitChange()
+			state = StateOf(condition.subcondition)
 			timer.Stop()
 			timer = realTimer{timer: time.NewTimer(condition.Delay)}
 		case <-timer.Wait():
 			// The timer expired. Let's apply the last recovered state.
-			condition.Condition.(*ManualCondition).Set(state)
+			condition.Condition.(*ManualCondition).setState(satisfied, !state.Known())
 			timer = foreverTimer{
 				channel: make(chan time.Time),
 			}
@@ -35,3 +35,52 @@ func TestDelay(t *testing.T) {
 	m.Set(true)
 	assertConditionState(t, condition, true, "waiting again for a while")
 }
+
+func TestDelayUnknown(t *testing.T) {
+	m := NewManualCondition(true)
+	condition := Delay(m, 10*time.Millisecond)
+	defer condition.Close()
+
+	ch := make(chan State, 10)
+	unregister := condition.Register(NewStateChannelObserver(ch))
+	defer unregister()
+
+	if state := <-ch; state != StateTrue {
+		t.Errorf("expected %s, got %s", StateTrue, state)
+	}
+
+	m.SetUnknown()
+
+	if state := <-ch; state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+
+	if state := StateOf(condition); state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+}
+
+func TestDelayUnknownPolicy(t *testing.T) {
+	m := NewManualCondition(true)
+	m.SetUnknownPolicy(UnknownFalse)
+	condition := Delay(m, 10*time.Millisecond)
+	defer condition.Close()
+
+	ch := make(chan State, 10)
+	unregister := condition.Register(NewStateChannelObserver(ch))
+	defer unregister()
+
+	if state := <-ch; state != StateTrue {
+		t.Errorf("expected %s, got %s", StateTrue, state)
+	}
+
+	m.SetUnknown()
+
+	if state := <-ch; state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+
+	if satisfied, _ := condition.GetAndWaitChange(); satisfied {
+		t.Error("expected the unknown state to resolve as unsatisfied")
+	}
+}
@@ -6,11 +6,19 @@ type unclosableCondition struct {
 
 func (unclosableCondition) Close() error { return nil }
 
+func (c unclosableCondition) State() State { return StateOf(c.Condition) }
+
+func (c unclosableCondition) unwrap() Condition { return c.Condition }
+
 type unclosableSettableCondition struct {
 	Condition
 	Settable
 }
 
+func (c unclosableSettableCondition) State() State { return StateOf(c.Condition) }
+
+func (c unclosableSettableCondition) unwrap() Condition { return c.Condition }
+
 // Dereference a condition by making its Close() function a no-op.
 func Dereference(condition Condition) Condition {
 	if settable, ok := condition.(Settable); ok {
@@ -0,0 +1,251 @@
+package conditional
+
+import (
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// DefaultFilePollPeriod is the period at which a file condition checks its
+// path when change notifications are not available.
+const DefaultFilePollPeriod = time.Second
+
+type fileCondition struct {
+	Condition
+	path       string
+	pattern    *regexp.Regexp
+	debounce   time.Duration
+	pollPeriod time.Duration
+	watcher    fileWatcher
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// FileConditionOption represents an option for a file condition.
+type FileConditionOption interface {
+	apply(condition *fileCondition)
+}
+
+// FilePatternOption makes a file condition match the content of its file
+// against the specified pattern, instead of merely checking its existence.
+type FilePatternOption struct {
+	Pattern *regexp.Regexp
+}
+
+func (o FilePatternOption) apply(condition *fileCondition) {
+	condition.pattern = o.Pattern
+}
+
+// FileDebounceOption makes a file condition wait for its file to stop
+// changing for the specified delay before checking it.
+type FileDebounceOption struct {
+	Delay time.Duration
+}
+
+func (o FileDebounceOption) apply(condition *fileCondition) {
+	condition.debounce = o.Delay
+}
+
+// FilePollPeriodOption defines the period at which a file condition checks
+// its path when change notifications are not available.
+type FilePollPeriodOption struct {
+	Period time.Duration
+}
+
+func (o FilePollPeriodOption) apply(condition *fileCondition) {
+	condition.pollPeriod = o.Period
+}
+
+// NewFileCondition creates a condition that is satisfied as long as the
+// specified path exists and, if a FilePatternOption is specified, its content
+// matches the pattern.
+//
+// The condition is updated as soon as the path changes, using inotify on
+// Linux. On other platforms or if the parent directory of the path cannot be
+// watched, the path is polled instead.
+//
+// If the path exists but cannot be read, the condition becomes unknown.
+func NewFileCondition(path string, options ...FileConditionOption) Condition {
+	condition := &fileCondition{
+		path:       path,
+		pollPeriod: DefaultFilePollPeriod,
+		done:       make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option.apply(condition)
+	}
+
+	condition.Condition = NewManualCondition(false)
+	condition.watcher = newFileWatcher(path, condition.pollPeriod)
+	condition.check()
+
+	go condition.run(condition.done)
+
+	return condition
+}
+
+// State returns the current tri-state of the condition.
+func (c *fileCondition) State() State {
+	return StateOf(c.Condition)
+}
+
+func (c *fileCondition) run(done <-chan struct{}) {
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	for {
+		select {
+		case <-done:
+			if timer != nil {
+				timer.Stop()
+			}
+
+			return
+		case <-c.watcher.Events():
+			if c.debounce <= 0 {
+				c.check()
+				continue
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+
+			timer = time.NewTimer(c.debounce)
+			timeout = timer.C
+		case <-timeout:
+			timeout = nil
+			c.check()
+		}
+	}
+}
+
+func (c *fileCondition) check() {
+	c.Condition.(*ManualCondition).SetState(c.state())
+}
+
+func (c *fileCondition) state() State {
+	if c.pattern == nil {
+		_, err := os.Stat(c.path)
+
+		if os.IsNotExist(err) {
+			return StateFalse
+		}
+
+		if err != nil {
+			return StateUnknown
+		}
+
+		return StateTrue
+	}
+
+	data, err := ioutil.ReadFile(c.path)
+
+	if os.IsNotExist(err) {
+		return StateFalse
+	}
+
+	if err != nil {
+		return StateUnknown
+	}
+
+	return StateFromBool(c.pattern.Match(data))
+}
+
+// Close terminates the condition.
+//
+// Calling Close() twice or more has no effect.
+func (c *fileCondition) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.watcher.Close()
+	})
+
+	return c.Condition.Close()
+}
+
+// A fileWatcher notifies the changes of a path.
+type fileWatcher interface {
+	Events() <-chan struct{}
+	Close() error
+}
+
+// pollingFileWatcher notifies the changes of a path by periodically comparing
+// its existence, size and modification time.
+type pollingFileWatcher struct {
+	path   string
+	events chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newPollingFileWatcher(path string, period time.Duration) fileWatcher {
+	watcher := &pollingFileWatcher{
+		path:   path,
+		events: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	go watcher.run(period, watcher.stat())
+
+	return watcher
+}
+
+func (w *pollingFileWatcher) Events() <-chan struct{} {
+	return w.events
+}
+
+func (w *pollingFileWatcher) run(period time.Duration, last fileStat) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if current := w.stat(); current != last {
+				last = current
+				notifyFileEvent(w.events)
+			}
+		}
+	}
+}
+
+type fileStat struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func (w *pollingFileWatcher) stat() fileStat {
+	info, err := os.Stat(w.path)
+
+	if err != nil {
+		return fileStat{}
+	}
+
+	return fileStat{
+		exists:  true,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+}
+
+func (w *pollingFileWatcher) Close() error {
+	w.once.Do(func() { close(w.done) })
+
+	return nil
+}
+
+// notifyFileEvent notifies an event without blocking, coalescing it with any
+// pending one.
+func notifyFileEvent(events chan<- struct{}) {
+	select {
+	case events <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,99 @@
+package conditional
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CREATE |
+	syscall.IN_DELETE |
+	syscall.IN_MODIFY |
+	syscall.IN_ATTRIB |
+	syscall.IN_CLOSE_WRITE |
+	syscall.IN_MOVED_FROM |
+	syscall.IN_MOVED_TO |
+	syscall.IN_DELETE_SELF |
+	syscall.IN_MOVE_SELF
+
+// inotifyFileWatcher notifies the changes of a path by watching its parent
+// directory with inotify, so that creations and removals are caught as well.
+type inotifyFileWatcher struct {
+	name   string
+	file   *os.File
+	events chan struct{}
+}
+
+func newFileWatcher(path string, pollPeriod time.Duration) fileWatcher {
+	watcher, err := newInotifyFileWatcher(path)
+
+	if err != nil {
+		return newPollingFileWatcher(path, pollPeriod)
+	}
+
+	return watcher
+}
+
+func newInotifyFileWatcher(path string) (*inotifyFileWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = syscall.InotifyAddWatch(fd, filepath.Dir(path), inotifyMask); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	watcher := &inotifyFileWatcher{
+		name:   filepath.Base(path),
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		events: make(chan struct{}, 1),
+	}
+
+	go watcher.run()
+
+	return watcher, nil
+}
+
+func (w *inotifyFileWatcher) Events() <-chan struct{} {
+	return w.events
+}
+
+func (w *inotifyFileWatcher) run() {
+	buffer := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+
+	for {
+		n, err := w.file.Read(buffer)
+
+		if err != nil {
+			return
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+			start := offset + syscall.SizeofInotifyEvent
+			end := start + int(event.Len)
+			offset = end
+
+			if end > n {
+				break
+			}
+
+			name := string(bytes.TrimRight(buffer[start:end], "\x00"))
+
+			// Events about the directory itself have no name.
+			if name == "" || name == w.name {
+				notifyFileEvent(w.events)
+			}
+		}
+	}
+}
+
+func (w *inotifyFileWatcher) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+// +build !linux
+
+package conditional
+
+import "time"
+
+func newFileWatcher(path string, pollPeriod time.Duration) fileWatcher {
+	return newPollingFileWatcher(path, pollPeriod)
+}
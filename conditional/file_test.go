@@ -0,0 +1,131 @@
+package conditional
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	return dir
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+}
+
+func TestFileCondition(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lock")
+	condition := NewFileCondition(path, FilePollPeriodOption{Period: time.Millisecond})
+	defer condition.Close()
+
+	assertConditionState(t, condition, false, "initialization to false")
+	assertConditionChanged(t, condition, false, "file creation", func() { writeFile(t, path, "") })
+	assertConditionChanged(t, condition, true, "file removal", func() { os.Remove(path) })
+}
+
+func TestFileConditionPattern(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "status")
+	writeFile(t, path, "starting")
+
+	condition := NewFileCondition(path, FilePatternOption{Pattern: regexp.MustCompile("^ready$")}, FilePollPeriodOption{Period: time.Millisecond})
+	defer condition.Close()
+
+	assertConditionState(t, condition, false, "initialization to false")
+	assertConditionChanged(t, condition, false, "matching content", func() { writeFile(t, path, "ready") })
+	assertConditionChanged(t, condition, true, "non-matching content", func() { writeFile(t, path, "stopping") })
+}
+
+func TestFileConditionDebounce(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lock")
+	condition := NewFileCondition(path, FileDebounceOption{Delay: 50 * time.Millisecond}, FilePollPeriodOption{Period: time.Millisecond})
+	defer condition.Close()
+
+	writeFile(t, path, "")
+	os.Remove(path)
+	writeFile(t, path, "")
+
+	if state := StateOf(condition); state != StateFalse {
+		t.Error("expected the condition to be debounced")
+	}
+
+	assertConditionState(t, condition, true, "debounce")
+}
+
+func TestFileConditionUnknown(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	condition := NewFileCondition(dir, FilePatternOption{Pattern: regexp.MustCompile(".")})
+	defer condition.Close()
+
+	if state := StateOf(condition); state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+}
+
+func TestPollingFileWatcher(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lock")
+	watcher := newPollingFileWatcher(path, time.Millisecond)
+	defer watcher.Close()
+
+	writeFile(t, path, "")
+
+	select {
+	case <-watcher.Events():
+	case <-time.After(time.Second):
+		t.Error("expected an event")
+	}
+}
+
+func TestFileConditionClose(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	assertCloseCondition(t, NewFileCondition(filepath.Join(dir, "lock")))
+}
+
+func TestFileConditionCloseTwice(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	condition := NewFileCondition(filepath.Join(dir, "lock"))
+
+	if err := condition.Close(); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if err := condition.Close(); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+}
+
+func TestPollingFileWatcherCloseTwice(t *testing.T) {
+	watcher := newPollingFileWatcher("lock", time.Millisecond)
+
+	watcher.Close()
+	watcher.Close()
+}
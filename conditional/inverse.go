@@ -2,6 +2,8 @@ package conditional
 
 // Inverse returns a Condition that has the reversed satisfied state as the one
 // provided.
+//
+// An unknown condition stays unknown once inversed.
 func Inverse(condition Condition) Condition {
 	return &inversedCondition{Condition: condition}
 }
@@ -26,3 +28,43 @@ func (c *inversedCondition) GetAndWaitChange() (bool, <-chan error) {
 
 	return !state, channel
 }
+
+// State returns the current tri-state of the condition.
+func (c *inversedCondition) State() State {
+	return StateOf(c.Condition).Not()
+}
+
+func (c *inversedCondition) unwrap() Condition {
+	return c.Condition
+}
+
+// Register an observer for changes.
+//
+// The observer gets called with the inversed states.
+func (c *inversedCondition) Register(observer ConditionStateObserver) func() {
+	if observer, ok := observer.(StateObserver); ok {
+		return c.Condition.Register(inversedStateObserver{observer: observer})
+	}
+
+	return c.Condition.Register(inversedObserver{observer: observer})
+}
+
+type inversedObserver struct {
+	observer ConditionStateObserver
+}
+
+func (o inversedObserver) OnChange(state bool) {
+	o.observer.OnChange(!state)
+}
+
+type inversedStateObserver struct {
+	observer StateObserver
+}
+
+func (o inversedStateObserver) OnChange(state bool) {
+	o.observer.OnStateChange(StateFromBool(!state))
+}
+
+func (o inversedStateObserver) OnStateChange(state State) {
+	o.observer.OnStateChange(state.Not())
+}
@@ -21,3 +21,41 @@ func TestInverse(t *testing.T) {
 	assertConditionState(t, condition, false, "fourth set to true")
 	assertConditionChanged(t, condition, false, "fifth set to false", func() { m.Set(false) })
 }
+
+func TestInverseRegister(t *testing.T) {
+	m := NewManualCondition(false)
+	condition := Inverse(m)
+	defer condition.Close()
+
+	ch := make(chan bool, 10)
+	unregister := condition.Register(NewChannelObserver(ch))
+	defer unregister()
+
+	if state := <-ch; !state {
+		t.Error("expected the observer to get the inversed state")
+	}
+
+	stateCh := make(chan State, 10)
+	unregisterState := condition.Register(NewStateChannelObserver(stateCh))
+	defer unregisterState()
+
+	if state := <-stateCh; state != StateTrue {
+		t.Errorf("expected %s, got %s", StateTrue, state)
+	}
+
+	m.Set(true)
+
+	if state := <-ch; state {
+		t.Error("expected the observer to get the inversed state")
+	}
+
+	if state := <-stateCh; state != StateFalse {
+		t.Errorf("expected %s, got %s", StateFalse, state)
+	}
+
+	m.SetUnknown()
+
+	if state := <-stateCh; state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+}
@@ -11,11 +11,17 @@ type Settable interface {
 }
 
 // ManualCondition is a condition that can be set or unset explicitely.
+//
+// A ManualCondition can also be made unknown explicitely, in which case its
+// satisfied state is defined by its UnknownPolicy.
 type ManualCondition struct {
-	lock      sync.Mutex
-	satisfied bool
-	channels  []chan error
-	observers []ConditionStateObserver
+	lock           sync.Mutex
+	satisfied      bool
+	unknown        bool
+	unknownPolicy  UnknownPolicy
+	channels       []chan error
+	changeChannels []chan error
+	observers      []ConditionStateObserver
 }
 
 // NewManualCondition instantiates a new ManualCondition in the specified
@@ -60,11 +66,27 @@ func (c *ManualCondition) GetAndWaitChange() (bool, <-chan error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.channels = append(c.channels, channel)
+	c.changeChannels = append(c.changeChannels, channel)
 
 	return c.satisfied, channel
 }
 
+// State returns the current tri-state of the condition.
+func (c *ManualCondition) State() State {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.state()
+}
+
+func (c *ManualCondition) state() State {
+	if c.unknown {
+		return StateUnknown
+	}
+
+	return StateFromBool(c.satisfied)
+}
+
 // Close terminates the condition.
 //
 // Any pending wait on one of the returned channels via Wait() or
@@ -76,12 +98,13 @@ func (c *ManualCondition) Close() error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	for _, channel := range c.channels {
+	for _, channel := range append(c.channels, c.changeChannels...) {
 		channel <- ErrConditionClosed
 		close(channel)
 	}
 
 	c.channels = nil
+	c.changeChannels = nil
 	c.observers = nil
 
 	return nil
@@ -95,7 +118,7 @@ func (c *ManualCondition) Register(observer ConditionStateObserver) func() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	observer.OnChange(c.satisfied)
+	notifyObserver(observer, c.state(), c.satisfied)
 
 	c.observers = append(c.observers, observer)
 
@@ -119,21 +142,85 @@ func (c *ManualCondition) unregister(observer ConditionStateObserver) {
 //
 // Setting the condition to its current state is a no-op and does not unblock
 // any previously returned channel.
+//
+// If the condition was unknown, it becomes known again.
 func (c *ManualCondition) Set(satisfied bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if satisfied != c.satisfied {
-		c.satisfied = satisfied
+	c.update(satisfied, false)
+}
+
+// SetUnknown makes the condition unknown.
+//
+// The satisfied state of the condition is then defined by its UnknownPolicy.
+//
+// Making an unknown condition unknown is a no-op.
+func (c *ManualCondition) SetUnknown() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.update(c.unknownPolicy.Resolve(StateUnknown, c.satisfied), true)
+}
+
+// SetState defines the ManualCondition tri-state explicitely.
+func (c *ManualCondition) SetState(state State) {
+	if state.Known() {
+		c.Set(state == StateTrue)
+	} else {
+		c.SetUnknown()
+	}
+}
+
+// setState defines both the satisfied state and the unknown flag of the
+// condition, bypassing its UnknownPolicy.
+//
+// This is used by wrappers that mirror an already resolved condition.
+func (c *ManualCondition) setState(satisfied bool, unknown bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.update(satisfied, unknown)
+}
+
+// SetUnknownPolicy defines how the condition is considered while unknown.
+//
+// The policy only applies to future transitions to the unknown state.
+func (c *ManualCondition) SetUnknownPolicy(policy UnknownPolicy) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.unknownPolicy = policy
+}
+
+func (c *ManualCondition) update(satisfied bool, unknown bool) {
+	satisfiedChanged := satisfied != c.satisfied
+
+	if !satisfiedChanged && unknown == c.unknown {
+		return
+	}
+
+	c.satisfied = satisfied
+	c.unknown = unknown
+	state := c.state()
 
+	if satisfiedChanged {
 		for _, channel := range c.channels {
 			close(channel)
 		}
 
 		c.channels = make([]chan error, 0, 0)
+	}
+
+	for _, channel := range c.changeChannels {
+		close(channel)
+	}
+
+	c.changeChannels = make([]chan error, 0, 0)
 
-		for _, observer := range c.observers {
-			observer.OnChange(satisfied)
+	for _, observer := range c.observers {
+		if _, ok := observer.(StateObserver); ok || satisfiedChanged {
+			notifyObserver(observer, state, satisfied)
 		}
 	}
 }
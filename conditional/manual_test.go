@@ -32,3 +32,63 @@ func TestManualCondition(t *testing.T) {
 	assertConditionState(t, condition, true, "fourth set to true")
 	assertConditionChanged(t, condition, true, "fifth set to false", func() { condition.Set(false) })
 }
+
+func TestManualConditionUnknown(t *testing.T) {
+	condition := NewManualCondition(true)
+	defer assertCloseCondition(t, condition)
+
+	ch := make(chan State, 10)
+	unregister := condition.Register(NewStateChannelObserver(ch))
+	defer unregister()
+
+	if state := <-ch; state != StateTrue {
+		t.Errorf("expected %s on registration, got %s", StateTrue, state)
+	}
+
+	wait := condition.Wait(false)
+	_, change := condition.GetAndWaitChange()
+
+	condition.SetUnknown()
+	assertConditionState(t, condition, true, "set to unknown with the hold policy")
+
+	if state := <-ch; state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+
+	if !<-waitChannel(change) {
+		t.Error("expected the change channel to be unblocked")
+	}
+
+	select {
+	case <-wait:
+		t.Error("expected the wait channel to stay blocked")
+	default:
+	}
+
+	condition.SetUnknown()
+	condition.SetState(StateTrue)
+
+	if state := <-ch; state != StateTrue {
+		t.Errorf("expected %s, got %s", StateTrue, state)
+	}
+
+	condition.SetUnknownPolicy(UnknownFalse)
+	assertConditionChanged(t, condition, true, "set to unknown with the false policy", func() { condition.SetState(StateUnknown) })
+
+	if state := condition.State(); state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+}
+
+// assertNoChangeChannel asserts that getting the state of the specified
+// condition did not register a change channel on its manual condition.
+func assertNoChangeChannel(t *testing.T, condition Condition, manual *ManualCondition) {
+	StateOf(condition)
+
+	manual.lock.Lock()
+	defer manual.lock.Unlock()
+
+	if len(manual.changeChannels) != 0 {
+		t.Errorf("expected no change channel, got %d", len(manual.changeChannels))
+	}
+}
@@ -19,3 +19,38 @@ func NewChannelObserver(ch chan<- bool) ConditionStateObserver {
 func (o channelObserver) OnChange(state bool) {
 	o.ch <- state
 }
+
+// StateObserver represents a type that listens on condition tri-state
+// changes.
+//
+// A ConditionStateObserver that also implements StateObserver gets called
+// through OnStateChange instead of OnChange.
+type StateObserver interface {
+	OnStateChange(State)
+}
+
+type stateChannelObserver struct {
+	ch chan<- State
+}
+
+// NewStateChannelObserver creates a new condition state observer that writes
+// the tri-state changes to the specified channel.
+func NewStateChannelObserver(ch chan<- State) ConditionStateObserver {
+	return stateChannelObserver{ch: ch}
+}
+
+func (o stateChannelObserver) OnChange(state bool) {
+	o.ch <- StateFromBool(state)
+}
+
+func (o stateChannelObserver) OnStateChange(state State) {
+	o.ch <- state
+}
+
+func notifyObserver(observer ConditionStateObserver, state State, satisfied bool) {
+	if stateObserver, ok := observer.(StateObserver); ok {
+		stateObserver.OnStateChange(state)
+	} else {
+		observer.OnChange(satisfied)
+	}
+}
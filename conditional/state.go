@@ -0,0 +1,108 @@
+package conditional
+
+// State represents the tri-state of a condition: satisfied, unsatisfied or
+// unknown.
+type State int
+
+const (
+	// StateUnknown is the state of a condition whose satisfied state could
+	// not be determined.
+	StateUnknown State = iota
+
+	// StateFalse is the state of an unsatisfied condition.
+	StateFalse
+
+	// StateTrue is the state of a satisfied condition.
+	StateTrue
+)
+
+// StateFromBool returns the known state that matches the specified satisfied
+// state.
+func StateFromBool(satisfied bool) State {
+	if satisfied {
+		return StateTrue
+	}
+
+	return StateFalse
+}
+
+// Known tells whether the state is not StateUnknown.
+func (s State) Known() bool {
+	return s != StateUnknown
+}
+
+// Not returns the inverse state. StateUnknown is its own inverse.
+func (s State) Not() State {
+	switch s {
+	case StateTrue:
+		return StateFalse
+	case StateFalse:
+		return StateTrue
+	}
+
+	return StateUnknown
+}
+
+func (s State) String() string {
+	switch s {
+	case StateTrue:
+		return "true"
+	case StateFalse:
+		return "false"
+	}
+
+	return "unknown"
+}
+
+// UnknownPolicy defines how an unknown state is turned into a satisfied
+// state.
+type UnknownPolicy int
+
+const (
+	// UnknownHold keeps the last known satisfied state.
+	UnknownHold UnknownPolicy = iota
+
+	// UnknownFalse considers unknown states as unsatisfied.
+	UnknownFalse
+
+	// UnknownTrue considers unknown states as satisfied.
+	UnknownTrue
+
+	// UnknownPropagate only makes sense for composite conditions, which
+	// become unknown whenever their result depends on the state of unknown
+	// sub-conditions. Other users treat it as UnknownHold.
+	UnknownPropagate
+)
+
+// Resolve returns the satisfied state to use for the specified state, given
+// the last known satisfied state.
+func (p UnknownPolicy) Resolve(state State, last bool) bool {
+	switch state {
+	case StateTrue:
+		return true
+	case StateFalse:
+		return false
+	}
+
+	switch p {
+	case UnknownFalse:
+		return false
+	case UnknownTrue:
+		return true
+	}
+
+	return last
+}
+
+func (p UnknownPolicy) String() string {
+	switch p {
+	case UnknownFalse:
+		return "false"
+	case UnknownTrue:
+		return "true"
+	case UnknownPropagate:
+		return "propagate"
+	}
+
+	return "hold"
+}
@@ -0,0 +1,86 @@
+package conditional
+
+import "testing"
+
+func TestState(t *testing.T) {
+	testCases := []struct {
+		State  State
+		Known  bool
+		Not    State
+		String string
+	}{
+		{StateTrue, true, StateFalse, "true"},
+		{StateFalse, true, StateTrue, "false"},
+		{StateUnknown, false, StateUnknown, "unknown"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.String, func(t *testing.T) {
+			if value := testCase.State.Known(); value != testCase.Known {
+				t.Errorf("expected known to be %v, got %v", testCase.Known, value)
+			}
+
+			if value := testCase.State.Not(); value != testCase.Not {
+				t.Errorf("expected inverse to be %s, got %s", testCase.Not, value)
+			}
+
+			if value := testCase.State.String(); value != testCase.String {
+				t.Errorf("expected %s, got %s", testCase.String, value)
+			}
+		})
+	}
+}
+
+func TestUnknownPolicyResolve(t *testing.T) {
+	testCases := []struct {
+		Policy   UnknownPolicy
+		State    State
+		Last     bool
+		Expected bool
+	}{
+		{UnknownHold, StateTrue, false, true},
+		{UnknownHold, StateFalse, true, false},
+		{UnknownHold, StateUnknown, true, true},
+		{UnknownHold, StateUnknown, false, false},
+		{UnknownFalse, StateUnknown, true, false},
+		{UnknownTrue, StateUnknown, false, true},
+		{UnknownPropagate, StateUnknown, true, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Policy.String(), func(t *testing.T) {
+			if value := testCase.Policy.Resolve(testCase.State, testCase.Last); value != testCase.Expected {
+				t.Errorf("expected %v, got %v", testCase.Expected, value)
+			}
+		})
+	}
+}
+
+func TestStateOf(t *testing.T) {
+	condition := NewManualCondition(true)
+	defer condition.Close()
+
+	if state := StateOf(condition); state != StateTrue {
+		t.Errorf("expected %s, got %s", StateTrue, state)
+	}
+
+	condition.SetUnknown()
+
+	if state := StateOf(condition); state != StateUnknown {
+		t.Errorf("expected %s, got %s", StateUnknown, state)
+	}
+
+	if state := StateOf(Dereference(condition)); state != StateUnknown {
+		t.Errorf("expected %s through a dereference, got %s", StateUnknown, state)
+	}
+
+	if state := StateOf(Dereference(Inverse(condition))); state != StateUnknown {
+		t.Errorf("expected %s through an inverse, got %s", StateUnknown, state)
+	}
+
+	condition.Set(true)
+
+	if state := StateOf(Inverse(condition)); state != StateFalse {
+		t.Errorf("expected %s through an inverse, got %s", StateFalse, state)
+	}
+}
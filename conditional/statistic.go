@@ -0,0 +1,307 @@
+package conditional
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/intelux/gotomatic/executor"
+)
+
+// A Sample is a numeric value observed at a given time.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// A Statistic computes a value from a series of samples.
+type Statistic interface {
+	// Compute the statistic over the specified samples, sorted by time.
+	//
+	// If the statistic cannot be computed from the samples, false is
+	// returned.
+	Compute(samples []Sample) (float64, bool)
+	String() string
+}
+
+var (
+	// StatisticMean computes the average value of the samples.
+	StatisticMean Statistic = statisticMean{}
+
+	// StatisticMin computes the minimum value of the samples.
+	StatisticMin Statistic = statisticMin{}
+
+	// StatisticMax computes the maximum value of the samples.
+	StatisticMax Statistic = statisticMax{}
+)
+
+// StatisticPercentile computes the specified percentile of the samples, using
+// linear interpolation between the closest ranks.
+//
+// The percentile is clamped within [0, 100].
+func StatisticPercentile(percentile float64) Statistic {
+	return statisticPercentile{percentile: math.Max(0, math.Min(100, percentile))}
+}
+
+// StatisticDerivative computes the rate of change of the samples, expressed
+// in value units per specified duration.
+//
+// The rate of change is the slope of the least-squares line going through
+// the samples. At least two samples at different times are required.
+//
+// A non-positive duration is treated as one second.
+func StatisticDerivative(per time.Duration) Statistic {
+	if per <= 0 {
+		per = time.Second
+	}
+
+	return statisticDerivative{per: per}
+}
+
+// SamplePolicy defines which samples enter a Window.
+type SamplePolicy int
+
+const (
+	// SampleAll keeps every sample.
+	SampleAll SamplePolicy = iota
+
+	// SampleOnChange discards samples whose value equals the one of the
+	// previous sample.
+	SampleOnChange
+)
+
+// A Window defines the series of samples a Statistic is computed over.
+type Window struct {
+	// Duration is the maximum age of the samples in the window. A zero value
+	// means that samples never expire.
+	Duration time.Duration
+
+	// Size is the maximum number of samples in the window. A zero value means
+	// no limit.
+	Size int
+
+	// MinSamples is the minimum number of samples the window must contain
+	// for the statistic to be evaluated.
+	MinSamples int
+
+	// Policy defines which samples enter the window.
+	Policy SamplePolicy
+}
+
+func (w Window) add(samples []Sample, sample Sample) []Sample {
+	if w.Policy == SampleOnChange && len(samples) > 0 && samples[len(samples)-1].Value == sample.Value {
+		return w.expire(samples, sample.Time)
+	}
+
+	return w.expire(append(samples, sample), sample.Time)
+}
+
+func (w Window) expire(samples []Sample, now time.Time) []Sample {
+	start := 0
+
+	if w.Size > 0 && len(samples) > w.Size {
+		start = len(samples) - w.Size
+	}
+
+	if w.Duration > 0 {
+		for start < len(samples) && now.Sub(samples[start].Time) > w.Duration {
+			start++
+		}
+	}
+
+	return samples[start:]
+}
+
+type statisticCondition struct {
+	Condition
+	lock       sync.Mutex
+	statistic  Statistic
+	window     Window
+	comparison executor.Comparison
+	samples    []Sample
+	unregister func()
+}
+
+// NewStatisticCondition creates a new statistic condition.
+//
+// The condition is set when the statistic, computed over the window of
+// samples emitted by the source, matches the comparison.
+//
+// As long as the window does not hold enough samples for the statistic to be
+// computed, the condition keeps its previous state. The initial state of the
+// condition is unset.
+func NewStatisticCondition(source NumericSource, statistic Statistic, window Window, comparison executor.Comparison) Condition {
+	condition := &statisticCondition{
+		Condition:  NewManualCondition(false),
+		statistic:  statistic,
+		window:     window,
+		comparison: comparison,
+	}
+
+	condition.unregister = source.Register(condition)
+
+	return condition
+}
+
+func (c *statisticCondition) OnSample(value float64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.samples = c.window.add(c.samples, Sample{Time: time.Now(), Value: value})
+
+	if len(c.samples) < c.window.MinSamples {
+		return
+	}
+
+	if result, ok := c.statistic.Compute(c.samples); ok {
+		c.Condition.(*ManualCondition).Set(c.comparison.Match(result))
+	}
+}
+
+// Close terminates the condition.
+//
+// Any pending wait on one of the returned channels via Wait() or
+// WaitChange() will be unblocked.
+//
+// Calling Close() twice or more has no effect.
+// State returns the current tri-state of the condition.
+func (c *statisticCondition) State() State {
+	return StateOf(c.Condition)
+}
+
+func (c *statisticCondition) Close() error {
+	c.lock.Lock()
+	unregister := c.unregister
+	c.unregister = nil
+	c.lock.Unlock()
+
+	if unregister != nil {
+		unregister()
+	}
+
+	return c.Condition.Close()
+}
+
+type statisticMean struct{}
+
+func (statisticMean) Compute(samples []Sample) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	sum := 0.0
+
+	for _, sample := range samples {
+		sum += sample.Value
+	}
+
+	return sum / float64(len(samples)), true
+}
+
+func (statisticMean) String() string {
+	return "mean"
+}
+
+type statisticMin struct{}
+
+func (statisticMin) Compute(samples []Sample) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	result := samples[0].Value
+
+	for _, sample := range samples[1:] {
+		result = math.Min(result, sample.Value)
+	}
+
+	return result, true
+}
+
+func (statisticMin) String() string {
+	return "min"
+}
+
+type statisticMax struct{}
+
+func (statisticMax) Compute(samples []Sample) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	result := samples[0].Value
+
+	for _, sample := range samples[1:] {
+		result = math.Max(result, sample.Value)
+	}
+
+	return result, true
+}
+
+func (statisticMax) String() string {
+	return "max"
+}
+
+type statisticPercentile struct {
+	percentile float64
+}
+
+func (s statisticPercentile) Compute(samples []Sample) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	values := make([]float64, len(samples))
+
+	for i, sample := range samples {
+		values[i] = sample.Value
+	}
+
+	sort.Float64s(values)
+
+	rank := s.percentile / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower)), true
+}
+
+func (s statisticPercentile) String() string {
+	return fmt.Sprintf("percentile(%v)", s.percentile)
+}
+
+type statisticDerivative struct {
+	per time.Duration
+}
+
+func (s statisticDerivative) Compute(samples []Sample) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	n := float64(len(samples))
+	origin := samples[0].Time
+	var sumX, sumY, sumXX, sumXY float64
+
+	for _, sample := range samples {
+		x := float64(sample.Time.Sub(origin)) / float64(s.per)
+		sumX += x
+		sumY += sample.Value
+		sumXX += x * x
+		sumXY += x * sample.Value
+	}
+
+	denominator := n*sumXX - sumX*sumX
+
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return (n*sumXY - sumX*sumY) / denominator, true
+}
+
+func (s statisticDerivative) String() string {
+	return fmt.Sprintf("derivative(%s)", s.per)
+}
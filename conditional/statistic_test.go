@@ -0,0 +1,146 @@
+package conditional
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/executor"
+)
+
+func samplesAt(start time.Time, step time.Duration, values ...float64) []Sample {
+	samples := make([]Sample, len(values))
+
+	for i, value := range values {
+		samples[i] = Sample{Time: start.Add(time.Duration(i) * step), Value: value}
+	}
+
+	return samples
+}
+
+func TestStatistics(t *testing.T) {
+	samples := samplesAt(time.Now(), time.Minute, 4, 1, 3, 2, 5)
+
+	testCases := []struct {
+		Statistic Statistic
+		Expected  float64
+	}{
+		{StatisticMean, 3},
+		{StatisticMin, 1},
+		{StatisticMax, 5},
+		{StatisticPercentile(0), 1},
+		{StatisticPercentile(50), 3},
+		{StatisticPercentile(100), 5},
+		{StatisticPercentile(75), 4},
+		{StatisticPercentile(90), 4.6},
+		{StatisticDerivative(time.Minute), 0.3},
+		{StatisticDerivative(time.Hour), 18},
+		{StatisticPercentile(-10), 1},
+		{StatisticPercentile(150), 5},
+		{StatisticDerivative(0), 0.005},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Statistic.String(), func(t *testing.T) {
+			value, ok := testCase.Statistic.Compute(samples)
+
+			if !ok {
+				t.Fatal("expected the statistic to be computable")
+			}
+
+			if diff := value - testCase.Expected; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("expected %v, got %v", testCase.Expected, value)
+			}
+		})
+	}
+}
+
+func TestStatisticsNotComputable(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		Statistic Statistic
+		Samples   []Sample
+	}{
+		{StatisticMean, nil},
+		{StatisticMin, nil},
+		{StatisticMax, nil},
+		{StatisticPercentile(50), nil},
+		{StatisticDerivative(time.Second), samplesAt(now, 0, 1)},
+		{StatisticDerivative(time.Second), samplesAt(now, 0, 1, 2)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Statistic.String(), func(t *testing.T) {
+			if _, ok := testCase.Statistic.Compute(testCase.Samples); ok {
+				t.Error("expected the statistic not to be computable")
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	now := time.Now()
+	window := Window{Duration: 3 * time.Minute, Size: 3}
+	var samples []Sample
+
+	for _, sample := range samplesAt(now, time.Minute, 1, 2, 3, 4) {
+		samples = window.add(samples, sample)
+	}
+
+	if len(samples) != 3 || samples[0].Value != 2 {
+		t.Errorf("expected the window to be limited in size, got: %v", samples)
+	}
+
+	samples = window.add(samples, Sample{Time: now.Add(6 * time.Minute), Value: 5})
+
+	if len(samples) != 2 || samples[0].Value != 4 {
+		t.Errorf("expected the window to be limited in duration, got: %v", samples)
+	}
+
+	window = Window{Policy: SampleOnChange}
+	samples = nil
+
+	for _, sample := range samplesAt(now, time.Minute, 1, 1, 2, 2, 1) {
+		samples = window.add(samples, sample)
+	}
+
+	if len(samples) != 3 {
+		t.Errorf("expected duplicate samples to be discarded, got: %v", samples)
+	}
+}
+
+func TestStatisticCondition(t *testing.T) {
+	variable := NewNumericVariable(1)
+	comparison := executor.Comparison{Operator: executor.OperatorGreater, Reference: 2}
+	condition := NewStatisticCondition(variable, StatisticMean, Window{Size: 2, MinSamples: 2}, comparison)
+	defer condition.Close()
+
+	assertConditionState(t, condition, false, "initialization")
+
+	variable.Set(5)
+	assertConditionState(t, condition, true, "mean of 1 and 5")
+
+	variable.Set(1)
+	assertConditionState(t, condition, true, "mean of 5 and 1")
+
+	assertConditionChanged(t, condition, true, "mean of 1 and 1", func() { variable.Set(1) })
+	assertNoChangeChannel(t, condition, condition.(*statisticCondition).Condition.(*ManualCondition))
+}
+
+func TestStatisticConditionMinSamples(t *testing.T) {
+	variable := NewNumericVariable(10)
+	comparison := executor.Comparison{Operator: executor.OperatorGreater, Reference: 2}
+	condition := NewStatisticCondition(variable, StatisticMax, Window{MinSamples: 3}, comparison)
+
+	assertConditionState(t, condition, false, "initialization")
+
+	variable.Set(10)
+	assertConditionState(t, condition, false, "second sample")
+
+	variable.Set(10)
+	assertConditionState(t, condition, true, "third sample")
+
+	condition.Close()
+	condition.Close()
+	variable.Set(0)
+}
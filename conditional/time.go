@@ -87,6 +87,11 @@ func (condition *TimeCondition) Close() error {
 	return condition.Condition.Close()
 }
 
+// State returns the current tri-state of the condition.
+func (condition *TimeCondition) State() State {
+	return StateOf(condition.Condition)
+}
+
 func (condition *TimeCondition) checkTime() {
 	var delay time.Duration
 
@@ -1,3 +1,4 @@
+//go:build !race
 // +build !race
 
 package conditional
@@ -35,6 +36,7 @@ func TestTimeCondition(t *testing.T) {
 	defer condition.Close()
 
 	assertConditionState(t, condition, false, "initialization before date")
+	assertNoChangeChannel(t, condition, condition.Condition.(*ManualCondition))
 
 	assertConditionChanged(t, condition, false, "a second passed", func() {
 		now = now.Add(time.Second)
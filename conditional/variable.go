@@ -0,0 +1,188 @@
+package conditional
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/intelux/gotomatic/executor"
+)
+
+// NumericObserver represents a type that listens on numeric samples.
+type NumericObserver interface {
+	OnSample(float64)
+}
+
+// A NumericSource produces numeric samples.
+//
+// All methods on a NumericSource are thread-safe.
+type NumericSource interface {
+	// Register an observer for samples.
+	//
+	// Any new sample will cause the observer to be called with its value
+	// until the returned cancel function is called.
+	Register(NumericObserver) func()
+}
+
+type numericObservers struct {
+	lock      sync.Mutex
+	observers []NumericObserver
+}
+
+func (o *numericObservers) add(observer NumericObserver) int {
+	o.observers = append(o.observers, observer)
+
+	return len(o.observers)
+}
+
+func (o *numericObservers) remove(observer NumericObserver) int {
+	for i, ob := range o.observers {
+		if ob == observer {
+			o.observers = append(o.observers[:i], o.observers[i+1:]...)
+			break
+		}
+	}
+
+	return len(o.observers)
+}
+
+func (o *numericObservers) notify(value float64) {
+	for _, observer := range o.observers {
+		observer.OnSample(value)
+	}
+}
+
+// NumericVariable is a numeric value that can be set explicitely.
+type NumericVariable struct {
+	numericObservers
+	value float64
+}
+
+// NewNumericVariable instantiates a new NumericVariable with the specified
+// initial value.
+func NewNumericVariable(value float64) *NumericVariable {
+	return &NumericVariable{value: value}
+}
+
+// Get returns the current value of the variable.
+func (v *NumericVariable) Get() float64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.value
+}
+
+// Set defines the value of the variable.
+//
+// Every call emits a sample, even if the value does not change.
+func (v *NumericVariable) Set(value float64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.value = value
+	v.notify(value)
+}
+
+// Register an observer for samples.
+//
+// The observer is called immediately with the current value.
+func (v *NumericVariable) Register(observer NumericObserver) func() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	observer.OnSample(v.value)
+	v.add(observer)
+
+	return func() {
+		v.lock.Lock()
+		defer v.lock.Unlock()
+
+		v.remove(observer)
+	}
+}
+
+// NumericPoller is a NumericSource that calls a NumericExecutor periodically.
+//
+// The executor only gets called as long as at least one observer is
+// registered. Failed calls do not emit any sample.
+type NumericPoller struct {
+	numericObservers
+	period   time.Duration
+	executor executor.NumericExecutor
+	done     chan struct{}
+}
+
+// DefaultNumericPollPeriod is the period of a NumericPoller instantiated with
+// a non-positive period.
+const DefaultNumericPollPeriod = time.Second
+
+// NewNumericPoller instantiates a new NumericPoller.
+//
+// A non-positive period is replaced by DefaultNumericPollPeriod.
+func NewNumericPoller(period time.Duration, executor executor.NumericExecutor) *NumericPoller {
+	if period <= 0 {
+		period = DefaultNumericPollPeriod
+	}
+
+	return &NumericPoller{
+		period:   period,
+		executor: executor,
+	}
+}
+
+// Register an observer for samples.
+//
+// The first registered observer starts the polling.
+func (p *NumericPoller) Register(observer NumericObserver) func() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.add(observer) == 1 {
+		p.done = make(chan struct{})
+		go p.run(p.done)
+	}
+
+	return func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		if p.remove(observer) == 0 && p.done != nil {
+			close(p.done)
+			p.done = nil
+		}
+	}
+}
+
+func (p *NumericPoller) run(done <-chan struct{}) {
+	ticker := time.NewTicker(p.period)
+	defer ticker.Stop()
+
+	for {
+		p.poll(done)
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *NumericPoller) poll(done <-chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.period)
+	value, err := p.executor(ctx)
+	cancel()
+
+	if err != nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	select {
+	case <-done:
+	default:
+		p.notify(value)
+	}
+}
@@ -0,0 +1,85 @@
+package conditional
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type channelNumericObserver chan float64
+
+func (o channelNumericObserver) OnSample(value float64) {
+	o <- value
+}
+
+func assertSample(t *testing.T, ch <-chan float64, expected float64) {
+	select {
+	case value := <-ch:
+		if value != expected {
+			t.Errorf("expected sample %v, got %v", expected, value)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected sample %v, got none", expected)
+	}
+}
+
+func TestNumericVariable(t *testing.T) {
+	variable := NewNumericVariable(1)
+
+	if value := variable.Get(); value != 1 {
+		t.Errorf("expected 1, got %v", value)
+	}
+
+	ch := make(channelNumericObserver, 10)
+	unregister := variable.Register(ch)
+	assertSample(t, ch, 1)
+
+	variable.Set(2)
+	assertSample(t, ch, 2)
+
+	if value := variable.Get(); value != 2 {
+		t.Errorf("expected 2, got %v", value)
+	}
+
+	unregister()
+	variable.Set(3)
+
+	if len(ch) != 0 {
+		t.Error("expected no sample after unregistering")
+	}
+}
+
+func TestNumericPoller(t *testing.T) {
+	values := make(chan float64, 1)
+	poller := NewNumericPoller(time.Millisecond, func(context.Context) (float64, error) {
+		select {
+		case value := <-values:
+			return value, nil
+		default:
+			return 0, errors.New("no value")
+		}
+	})
+
+	ch := make(channelNumericObserver, 10)
+	unregister := poller.Register(ch)
+
+	values <- 1
+	assertSample(t, ch, 1)
+	values <- 2
+	assertSample(t, ch, 2)
+
+	unregister()
+	unregister = poller.Register(ch)
+	values <- 3
+	assertSample(t, ch, 3)
+	unregister()
+}
+
+func TestNumericPollerDefaultPeriod(t *testing.T) {
+	poller := NewNumericPoller(0, func(context.Context) (float64, error) { return 0, nil })
+
+	if poller.period != DefaultNumericPollPeriod {
+		t.Errorf("expected a period of %s, got %s", DefaultNumericPollPeriod, poller.period)
+	}
+}
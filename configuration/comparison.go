@@ -0,0 +1,44 @@
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/intelux/gotomatic/executor"
+	"github.com/mitchellh/mapstructure"
+)
+
+func parseComparisonOperator(s string) (executor.ComparisonOperator, error) {
+	switch s {
+	case ">", "gt":
+		return executor.OperatorGreater, nil
+	case ">=", "ge":
+		return executor.OperatorGreaterOrEqual, nil
+	case "<", "lt":
+		return executor.OperatorLess, nil
+	case "<=", "le":
+		return executor.OperatorLessOrEqual, nil
+	case "==", "eq":
+		return executor.OperatorEqual, nil
+	case "!=", "ne":
+		return executor.OperatorNotEqual, nil
+	}
+
+	return nil, fmt.Errorf("unknown comparison operator \"%s\"", s)
+}
+
+// stringToComparisonOperatorFunc transforms a string into a comparison
+// operator.
+func stringToComparisonOperatorFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf((*executor.ComparisonOperator)(nil)).Elem() {
+			return data, nil
+		}
+
+		return parseComparisonOperator(data.(string))
+	}
+}
@@ -0,0 +1,47 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/intelux/gotomatic/executor"
+)
+
+func TestParseComparisonOperator(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected executor.ComparisonOperator
+	}{
+		{">", executor.OperatorGreater},
+		{"gt", executor.OperatorGreater},
+		{">=", executor.OperatorGreaterOrEqual},
+		{"ge", executor.OperatorGreaterOrEqual},
+		{"<", executor.OperatorLess},
+		{"lt", executor.OperatorLess},
+		{"<=", executor.OperatorLessOrEqual},
+		{"le", executor.OperatorLessOrEqual},
+		{"==", executor.OperatorEqual},
+		{"eq", executor.OperatorEqual},
+		{"!=", executor.OperatorNotEqual},
+		{"ne", executor.OperatorNotEqual},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			value, err := parseComparisonOperator(testCase.Value)
+
+			if err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if value != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, value)
+			}
+		})
+	}
+}
+
+func TestParseComparisonOperatorFailure(t *testing.T) {
+	if _, err := parseComparisonOperator("<>"); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -1,8 +1,10 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"time"
 
 	"github.com/intelux/gotomatic/conditional"
@@ -15,7 +17,7 @@ import (
 type conditionDecl struct {
 	Name    string
 	Type    string
-	Trigger *trigger.Trigger
+	Trigger *triggerDecl
 }
 
 type manualConditionParams struct {
@@ -33,6 +35,7 @@ type delayConditionParams struct {
 
 type compositeConditionParams struct {
 	Conditions []conditional.Condition
+	Unknown    conditional.UnknownPolicy
 }
 
 type timeConditionParams struct {
@@ -42,10 +45,124 @@ type timeConditionParams struct {
 }
 
 type cutOffConditionParams struct {
-	Up       uint
-	Down     uint
-	Period   time.Duration
-	Executor executor.Executor
+	Up           uint
+	Down         uint
+	Period       time.Duration
+	Executor     executor.Executor
+	StaleAfter   time.Duration `mapstructure:"stale-after"`
+	Unknown      conditional.UnknownPolicy
+	ProbeTimeout time.Duration `mapstructure:"probe-timeout"`
+}
+
+type fileConditionParams struct {
+	Path       string
+	Pattern    string
+	Debounce   time.Duration
+	PollPeriod time.Duration `mapstructure:"poll-period"`
+}
+
+func (p fileConditionParams) options() ([]conditional.FileConditionOption, error) {
+	options := []conditional.FileConditionOption{
+		conditional.FileDebounceOption{Delay: p.Debounce},
+		conditional.FilePollPeriodOption{Period: p.PollPeriod},
+	}
+
+	if p.Pattern != "" {
+		pattern, err := regexp.Compile(p.Pattern)
+
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, conditional.FilePatternOption{Pattern: pattern})
+	}
+
+	return options, nil
+}
+
+type statisticConditionParams struct {
+	Statistic  string
+	Percentile float64
+	Per        time.Duration
+	Window     time.Duration
+	Size       int
+	MinSamples int `mapstructure:"min-samples"`
+	Policy     string
+	Operator   executor.ComparisonOperator
+	Value      float64
+	Variable   string
+	Executor   executor.NumericExecutor
+	Period     time.Duration
+}
+
+func (p statisticConditionParams) statistic() (conditional.Statistic, error) {
+	switch p.Statistic {
+	case "mean":
+		return conditional.StatisticMean, nil
+	case "min":
+		return conditional.StatisticMin, nil
+	case "max":
+		return conditional.StatisticMax, nil
+	case "percentile":
+		if p.Percentile < 0 || p.Percentile > 100 {
+			return nil, fmt.Errorf("percentile must be within [0, 100] but was %v", p.Percentile)
+		}
+
+		return conditional.StatisticPercentile(p.Percentile), nil
+	case "derivative":
+		if p.Per <= 0 {
+			return nil, fmt.Errorf("the derivative duration must be positive but was %s", p.Per)
+		}
+
+		return conditional.StatisticDerivative(p.Per), nil
+	}
+
+	return nil, fmt.Errorf("unknown statistic \"%s\"", p.Statistic)
+}
+
+func (p statisticConditionParams) window() (conditional.Window, error) {
+	window := conditional.Window{
+		Duration:   p.Window,
+		Size:       p.Size,
+		MinSamples: p.MinSamples,
+	}
+
+	switch p.Policy {
+	case "all":
+		window.Policy = conditional.SampleAll
+	case "change":
+		window.Policy = conditional.SampleOnChange
+	default:
+		return window, fmt.Errorf("unknown sample policy \"%s\"", p.Policy)
+	}
+
+	return window, nil
+}
+
+func (c *configurationImpl) numericSource(p statisticConditionParams) (conditional.NumericSource, error) {
+	if p.Variable != "" {
+		if p.Executor != nil {
+			return nil, errors.New("a variable and an executor cannot be specified at the same time")
+		}
+
+		variable := c.GetVariable(p.Variable)
+
+		if variable == nil {
+			return nil, fmt.Errorf("no variable found with the name \"%s\"", p.Variable)
+		}
+
+		return variable, nil
+	}
+
+	if p.Executor == nil {
+		return nil, errors.New("either a variable or an executor must be specified")
+	}
+
+	if p.Period <= 0 {
+		return nil, fmt.Errorf("the polling period must be positive but was %s", p.Period)
+	}
+
+	return conditional.NewNumericPoller(p.Period, p.Executor), nil
 }
 
 func (c *configurationImpl) stringToCondition() mapstructure.DecodeHookFunc {
@@ -85,6 +202,18 @@ func (c *configurationImpl) mapToCondition() mapstructure.DecodeHookFunc {
 			return data, err
 		}
 
+		var tr *trigger.Trigger
+
+		if declaration.Trigger != nil {
+			value, err := c.newTrigger(*declaration.Trigger)
+
+			if err != nil {
+				return data, err
+			}
+
+			tr = &value
+		}
+
 		var condition conditional.Condition
 
 		switch declaration.Type {
@@ -119,7 +248,7 @@ func (c *configurationImpl) mapToCondition() mapstructure.DecodeHookFunc {
 				return data, err
 			}
 
-			condition = conditional.NewCompositeCondition(conditional.OperatorAnd, params.Conditions...)
+			condition = conditional.NewStateCompositeCondition(conditional.OperatorAnd, params.Unknown, params.Conditions...)
 		case "or":
 			var params compositeConditionParams
 
@@ -127,7 +256,7 @@ func (c *configurationImpl) mapToCondition() mapstructure.DecodeHookFunc {
 				return data, err
 			}
 
-			condition = conditional.NewCompositeCondition(conditional.OperatorOr, params.Conditions...)
+			condition = conditional.NewStateCompositeCondition(conditional.OperatorOr, params.Unknown, params.Conditions...)
 		case "xor":
 			var params compositeConditionParams
 
@@ -135,7 +264,7 @@ func (c *configurationImpl) mapToCondition() mapstructure.DecodeHookFunc {
 				return data, err
 			}
 
-			condition = conditional.NewCompositeCondition(conditional.OperatorXor, params.Conditions...)
+			condition = conditional.NewStateCompositeCondition(conditional.OperatorXor, params.Unknown, params.Conditions...)
 		case "time":
 			params := timeConditionParams{
 				Frequency: gtime.FrequencyYear,
@@ -158,7 +287,79 @@ func (c *configurationImpl) mapToCondition() mapstructure.DecodeHookFunc {
 				return data, err
 			}
 
-			condition = conditional.NewCutOffCondition(params.Up, params.Down, params.Period, params.Executor)
+			if params.Period <= 0 {
+				return data, fmt.Errorf("the period must be positive but was %s", params.Period)
+			}
+
+			condition = conditional.NewCutOffCondition(
+				params.Up,
+				params.Down,
+				params.Period,
+				params.Executor,
+				conditional.StaleTimeoutOption{Timeout: params.StaleAfter},
+				conditional.UnknownPolicyOption{Policy: params.Unknown},
+				conditional.ProbeSchedulerOption{Scheduler: c.scheduler},
+				conditional.ProbeTimeoutOption{Timeout: params.ProbeTimeout},
+			)
+		case "file":
+			params := fileConditionParams{
+				PollPeriod: conditional.DefaultFilePollPeriod,
+			}
+
+			if err := c.decode(data, &params); err != nil {
+				return data, err
+			}
+
+			if params.Path == "" {
+				return data, errors.New("a path is mandatory for that condition type")
+			}
+
+			options, err := params.options()
+
+			if err != nil {
+				return data, err
+			}
+
+			condition = conditional.NewFileCondition(params.Path, options...)
+		case "statistic":
+			params := statisticConditionParams{
+				Statistic:  "mean",
+				Percentile: 50,
+				Per:        time.Second,
+				MinSamples: 1,
+				Policy:     "all",
+				Operator:   executor.OperatorGreater,
+				Period:     time.Second * 5,
+			}
+
+			if err := c.decode(data, &params); err != nil {
+				return data, err
+			}
+
+			statistic, err := params.statistic()
+
+			if err != nil {
+				return data, err
+			}
+
+			window, err := params.window()
+
+			if err != nil {
+				return data, err
+			}
+
+			source, err := c.numericSource(params)
+
+			if err != nil {
+				return data, err
+			}
+
+			comparison := executor.Comparison{
+				Operator:  params.Operator,
+				Reference: params.Value,
+			}
+
+			condition = conditional.NewStatisticCondition(source, statistic, window, comparison)
 		default:
 			return data, fmt.Errorf("unknown condition type: %s", declaration.Type)
 		}
@@ -171,9 +372,10 @@ func (c *configurationImpl) mapToCondition() mapstructure.DecodeHookFunc {
 			condition = conditional.Dereference(condition)
 		}
 
-		if declaration.Trigger != nil {
+		if tr != nil {
 			c.triggers = append(c.triggers, conditionTrigger{
-				Trigger:   *declaration.Trigger,
+				Trigger:   *tr,
+				Name:      declaration.Name,
 				Condition: condition,
 			})
 		}
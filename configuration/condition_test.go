@@ -54,10 +54,51 @@ func TestMapToCondition(t *testing.T) {
 		{"fixture/invalid-composite-condition-xor-subcondition.yaml", true},
 		{"fixture/invalid-time-condition.yaml", true},
 		{"fixture/invalid-cut-off-condition.yaml", true},
+		{"fixture/invalid-cut-off-condition-period.yaml", true},
 		{"fixture/invalid-cut-off-condition-invalid-executor.yaml", true},
 		{"fixture/invalid-cut-off-condition-invalid-cmd-executor.yaml", true},
 		{"fixture/invalid-cut-off-condition-invalid-http-executor.yaml", true},
 		{"fixture/invalid-cut-off-condition-unknown-executor.yaml", true},
+		{"fixture/invalid-cut-off-condition-invalid-tcp-executor.yaml", true},
+		{"fixture/invalid-cut-off-condition-http-body-pattern.yaml", true},
+		{"fixture/invalid-cut-off-condition-http-json.yaml", true},
+		{"fixture/invalid-cut-off-condition-http-response-headers.yaml", true},
+		{"fixture/invalid-cut-off-condition-tcp-executor-no-address.yaml", true},
+		{"fixture/invalid-cut-off-condition-unknown-policy.yaml", true},
+		{"fixture/invalid-cut-off-condition-http-tls-ca.yaml", true},
+		{"fixture/invalid-cut-off-condition-http-tls-cert.yaml", true},
+		{"fixture/invalid-cut-off-condition-tls-version.yaml", true},
+		{"fixture/invalid-cut-off-condition-file-no-path.yaml", true},
+		{"fixture/invalid-cut-off-condition-file-modified-no-duration.yaml", true},
+		{"fixture/invalid-cut-off-condition-cmd-stdout.yaml", true},
+		{"fixture/invalid-cut-off-condition-cmd-stdout-contains.yaml", true},
+		{"fixture/invalid-cut-off-condition-all-no-executors.yaml", true},
+		{"fixture/invalid-cut-off-condition-not-no-executor.yaml", true},
+		{"fixture/invalid-cut-off-condition-cached-no-ttl.yaml", true},
+		{"fixture/invalid-cut-off-condition-dns-no-host.yaml", true},
+		{"fixture/invalid-cut-off-condition-dns-record.yaml", true},
+		{"fixture/invalid-cut-off-condition-dns-server.yaml", true},
+		{"fixture/invalid-cut-off-condition-prometheus-no-query.yaml", true},
+		{"fixture/invalid-cut-off-condition-prometheus-match.yaml", true},
+		{"fixture/invalid-statistic-condition-prometheus-no-url.yaml", true},
+		{"fixture/invalid-cut-off-condition-sql-driver.yaml", true},
+		{"fixture/invalid-cut-off-condition-sql-no-query.yaml", true},
+		{"fixture/invalid-cut-off-condition-process-no-name.yaml", true},
+		{"fixture/invalid-cut-off-condition-process-name-and-pidfile.yaml", true},
+		{"fixture/invalid-cut-off-condition-systemd-no-unit.yaml", true},
+		{"fixture/invalid-statistic-condition.yaml", true},
+		{"fixture/invalid-statistic-condition-unknown-statistic.yaml", true},
+		{"fixture/invalid-statistic-condition-percentile.yaml", true},
+		{"fixture/invalid-statistic-condition-derivative-per.yaml", true},
+		{"fixture/invalid-statistic-condition-period.yaml", true},
+		{"fixture/invalid-statistic-condition-policy.yaml", true},
+		{"fixture/invalid-statistic-condition-operator.yaml", true},
+		{"fixture/invalid-statistic-condition-no-source.yaml", true},
+		{"fixture/invalid-statistic-condition-two-sources.yaml", true},
+		{"fixture/invalid-statistic-condition-unknown-variable.yaml", true},
+		{"fixture/invalid-statistic-condition-unknown-executor.yaml", true},
+		{"fixture/invalid-file-condition-no-path.yaml", true},
+		{"fixture/invalid-file-condition-pattern.yaml", true},
 		{"fixture/unknown-type.yaml", true},
 		{"fixture/manual-condition.yaml", false},
 		{"fixture/inverse-condition.yaml", false},
@@ -67,7 +108,33 @@ func TestMapToCondition(t *testing.T) {
 		{"fixture/composite-condition-xor.yaml", false},
 		{"fixture/time-condition.yaml", false},
 		{"fixture/cut-off-condition-cmd.yaml", false},
+		{"fixture/cut-off-condition-cmd-options.yaml", false},
+		{"fixture/cut-off-condition-combinators.yaml", false},
+		{"fixture/cut-off-condition-dns.yaml", false},
+		{"fixture/cut-off-condition-prometheus.yaml", false},
+		{"fixture/statistic-condition-prometheus.yaml", false},
+		{"fixture/cut-off-condition-sql.yaml", false},
+		{"fixture/statistic-condition-sql.yaml", false},
 		{"fixture/cut-off-condition-http.yaml", false},
+		{"fixture/cut-off-condition-http-assertions.yaml", false},
+		{"fixture/cut-off-condition-stale.yaml", false},
+		{"fixture/cut-off-condition-tcp.yaml", false},
+		{"fixture/cut-off-condition-tcp-tls.yaml", false},
+		{"fixture/cut-off-condition-http-tls.yaml", false},
+		{"fixture/cut-off-condition-unix.yaml", false},
+		{"fixture/cut-off-condition-file-exists.yaml", false},
+		{"fixture/cut-off-condition-file-modified.yaml", false},
+		{"fixture/cut-off-condition-dir-entries.yaml", false},
+		{"fixture/cut-off-condition-free-space.yaml", false},
+		{"fixture/cut-off-condition-process.yaml", false},
+		{"fixture/cut-off-condition-pidfile.yaml", false},
+		{"fixture/cut-off-condition-systemd.yaml", false},
+		{"fixture/statistic-condition-free-space.yaml", false},
+		{"fixture/file-condition.yaml", false},
+		{"fixture/file-condition-pattern.yaml", false},
+		{"fixture/composite-condition-propagate.yaml", false},
+		{"fixture/statistic-condition.yaml", false},
+		{"fixture/statistic-condition-percentile.yaml", false},
 	}
 
 	for _, testCase := range testCases {
@@ -3,13 +3,17 @@ package configuration
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/intelux/gotomatic/conditional"
+	"github.com/intelux/gotomatic/executor"
 	"github.com/intelux/gotomatic/trigger"
 )
 
@@ -26,6 +30,24 @@ type Configuration interface {
 	// The caller should never use the passed-in condition directly ever again.
 	AddCondition(name string, condition conditional.Condition) error
 
+	// GetVariable returns a named numeric variable from the configuration, if
+	// it finds it.
+	GetVariable(name string) *conditional.NumericVariable
+
+	// AddVariable adds a named numeric variable to the configuration.
+	AddVariable(name string, variable *conditional.NumericVariable) error
+
+	// GetExecutor returns a named executor from the configuration, if it
+	// finds it.
+	GetExecutor(name string) executor.Executor
+
+	// AddExecutor adds a named executor to the configuration.
+	AddExecutor(name string, executor executor.Executor) error
+
+	// Scheduler returns the scheduler that calls the executors of the
+	// configuration conditions.
+	Scheduler() *executor.Scheduler
+
 	// Watch the configuration triggers until the specified context expires or
 	// the watch fails.
 	Watch(ctx context.Context) error
@@ -65,8 +87,43 @@ func Load(r io.Reader) (Configuration, error) {
 	return Decode(data)
 }
 
+// LoadFile loads a configuration from the specified YAML file.
+//
+// The triggers of the configuration can read the path of the file from their
+// context, with trigger.GetConfigurationFile.
+func LoadFile(path string) (Configuration, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	configuration, err := Load(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	configuration.(*configurationImpl).file = path
+
+	return configuration, nil
+}
+
+type variableDecl struct {
+	Name  string
+	Value float64
+}
+
+type schedulerParams struct {
+	Concurrency int
+	Jitter      float64
+}
+
 type conditionTrigger struct {
 	trigger.Trigger
+	Name      string
 	Condition conditional.Condition
 }
 
@@ -74,6 +131,93 @@ type conditionTrigger struct {
 func Decode(data interface{}) (Configuration, error) {
 	configuration := newConfigurationImpl()
 
+	var schedulerDecl struct {
+		Scheduler map[string]interface{}
+	}
+
+	if err := configuration.decode(data, &schedulerDecl); err != nil {
+		return nil, err
+	}
+
+	if schedulerDecl.Scheduler != nil {
+		params := schedulerParams{
+			Jitter: executor.DefaultSchedulerJitter,
+		}
+
+		if err := configuration.decode(schedulerDecl.Scheduler, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Concurrency < 0 {
+			return nil, errors.New("the scheduler concurrency cannot be negative")
+		}
+
+		if params.Jitter < 0 || params.Jitter > 1 {
+			return nil, fmt.Errorf("the scheduler jitter must be within [0, 1] but was %v", params.Jitter)
+		}
+
+		configuration.scheduler = executor.NewScheduler(
+			executor.SchedulerConcurrencyOption{Limit: params.Concurrency},
+			executor.SchedulerJitterOption{Jitter: params.Jitter},
+		)
+	}
+
+	var stateDecl struct {
+		StateFile string `mapstructure:"state-file"`
+	}
+
+	if err := configuration.decode(data, &stateDecl); err != nil {
+		return nil, err
+	}
+
+	if stateDecl.StateFile != "" {
+		store, err := trigger.NewFileStateStore(stateDecl.StateFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		configuration.stateStore = store
+	}
+
+	var variablesDecl struct {
+		Variables []variableDecl
+	}
+
+	if err := configuration.decode(data, &variablesDecl); err != nil {
+		return nil, err
+	}
+
+	for _, variable := range variablesDecl.Variables {
+		if variable.Name == "" {
+			return nil, errors.New("a name is mandatory for variables")
+		}
+
+		if err := configuration.AddVariable(variable.Name, conditional.NewNumericVariable(variable.Value)); err != nil {
+			return nil, err
+		}
+	}
+
+	var executorsDecl struct {
+		Executors []map[string]interface{}
+	}
+
+	if err := configuration.decode(data, &executorsDecl); err != nil {
+		return nil, err
+	}
+
+	for _, executorDecl := range executorsDecl.Executors {
+		if name, _ := executorDecl["name"].(string); name == "" {
+			return nil, errors.New("a name is mandatory for executors")
+		}
+
+		var value executor.Executor
+
+		if err := configuration.decode(executorDecl, &value); err != nil {
+			return nil, err
+		}
+	}
+
 	var decl struct {
 		Conditions []conditional.Condition
 	}
@@ -82,17 +226,39 @@ func Decode(data interface{}) (Configuration, error) {
 		return nil, err
 	}
 
+	for _, name := range configuration.conditionRefs {
+		condition := configuration.GetCondition(name)
+
+		if condition == nil {
+			return nil, fmt.Errorf("no condition found with the name \"%s\"", name)
+		}
+
+		if _, ok := condition.(conditional.Settable); !ok {
+			return nil, fmt.Errorf("condition \"%s\" cannot be set", name)
+		}
+	}
+
 	return configuration, nil
 }
 
 type configurationImpl struct {
 	namedConditions map[string]conditional.Condition
+	namedVariables  map[string]*conditional.NumericVariable
+	namedExecutors  map[string]executor.Executor
+	scheduler       *executor.Scheduler
 	triggers        []conditionTrigger
+	conditionRefs   []string
+	file            string
+	stateStore      trigger.StateStore
+	databases       []*sql.DB
 }
 
 func newConfigurationImpl() *configurationImpl {
 	return &configurationImpl{
 		namedConditions: make(map[string]conditional.Condition),
+		namedVariables:  make(map[string]*conditional.NumericVariable),
+		namedExecutors:  make(map[string]executor.Executor),
+		scheduler:       executor.NewScheduler(),
 	}
 }
 
@@ -116,13 +282,60 @@ func (c *configurationImpl) AddCondition(name string, condition conditional.Cond
 	return nil
 }
 
+func (c *configurationImpl) GetVariable(name string) *conditional.NumericVariable {
+	return c.namedVariables[name]
+}
+
+func (c *configurationImpl) AddVariable(name string, variable *conditional.NumericVariable) error {
+	if _, ok := c.namedVariables[name]; ok {
+		return fmt.Errorf("a variable named \"%s\" already exists", name)
+	}
+
+	c.namedVariables[name] = variable
+
+	return nil
+}
+
+func (c *configurationImpl) GetExecutor(name string) executor.Executor {
+	return c.namedExecutors[name]
+}
+
+func (c *configurationImpl) AddExecutor(name string, executor executor.Executor) error {
+	if _, ok := c.namedExecutors[name]; ok {
+		return fmt.Errorf("an executor named \"%s\" already exists", name)
+	}
+
+	c.namedExecutors[name] = executor
+
+	return nil
+}
+
+func (c *configurationImpl) Scheduler() *executor.Scheduler {
+	return c.scheduler
+}
+
 func (c *configurationImpl) Watch(ctx context.Context) error {
 	ch := make(chan error, len(c.triggers))
 	defer close(ch)
 
+	ctx = trigger.WithConditionGetter(ctx, c)
+
+	if c.file != "" {
+		ctx = trigger.WithConfigurationFile(ctx, c.file)
+	}
+
+	if c.stateStore != nil {
+		ctx = trigger.WithStateStore(ctx, c.stateStore)
+	}
+
 	for _, tr := range c.triggers {
 		go func(tr conditionTrigger) {
-			// TODO: inject the condition name in the context.
+			ctx := ctx
+
+			if tr.Name != "" {
+				ctx = trigger.WithConditionName(ctx, tr.Name)
+			}
+
 			if err := trigger.Watch(ctx, tr.Condition, tr.Trigger); err != nil {
 				ch <- err
 			}
@@ -142,7 +355,14 @@ func (c *configurationImpl) Clear() {
 		condition.Close()
 	}
 
+	for _, db := range c.databases {
+		db.Close()
+	}
+
 	c.namedConditions = nil
+	c.namedVariables = nil
+	c.namedExecutors = nil
+	c.databases = nil
 }
 
 func (c *configurationImpl) Close() {
@@ -2,8 +2,13 @@ package configuration
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/intelux/gotomatic/conditional"
 )
@@ -54,6 +59,129 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadFile(t *testing.T) {
+	conf, err := LoadFile("fixture/configuration.yaml")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	conf.Close()
+
+	if _, err = LoadFile("fixture/nonexisting.yaml"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if _, err = LoadFile("fixture/invalid.yaml"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestWatchContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "output")
+	os.Setenv("GOTOMATIC_TEST_OUTPUT", path)
+	defer os.Unsetenv("GOTOMATIC_TEST_OUTPUT")
+
+	conf, err := LoadFile("fixture/trigger-context.yaml")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go conf.Watch(ctx)
+
+	expected := "a 1 fixture/trigger-context.yaml a\n"
+	deadline := time.Now().Add(time.Second)
+
+	for {
+		data, _ := ioutil.ReadFile(path)
+
+		if string(data) == expected {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %q, got %q", expected, data)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchStateFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.json")
+	ioutil.WriteFile(path, []byte(`{"a": true}`), 0644)
+
+	conf, err := Load(strings.NewReader(fmt.Sprintf(`
+state-file: %s
+conditions:
+  - name: b
+    type: manual
+  - name: a
+    type: manual
+    state: true
+    trigger:
+      initial: changed
+      up:
+        type: set
+        condition: b
+`, path)))
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go conf.Watch(ctx)
+
+	b := conf.GetCondition("b")
+
+	select {
+	case <-b.Wait(true):
+		t.Fatal("expected the unchanged initial state not to fire")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	a := conf.GetCondition("a").(conditional.Settable)
+	a.Set(false)
+	a.Set(true)
+
+	select {
+	case <-b.Wait(true):
+	case <-time.After(time.Second):
+		t.Fatal("expected the state change to fire")
+	}
+
+	if _, err = Load(strings.NewReader("state-file: " + dir)); err == nil {
+		t.Error("expected an error for an unreadable state file")
+	}
+}
+
 func TestWatch(t *testing.T) {
 	f, _ := os.Open("fixture/configuration.yaml")
 	defer f.Close()
@@ -92,3 +220,175 @@ func TestWatchTriggerFailure(t *testing.T) {
 		t.Error("expected an error")
 	}
 }
+
+func TestLoadVariables(t *testing.T) {
+	f, _ := os.Open("fixture/variables.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	variable := conf.GetVariable("temperature")
+
+	if variable == nil {
+		t.Fatal("expected a variable")
+	}
+
+	condition := conf.GetCondition("too-hot")
+	variable.Set(40)
+
+	select {
+	case <-condition.Wait(true):
+	case <-time.After(time.Second):
+		t.Error("expected the condition to be satisfied")
+	}
+}
+
+func TestLoadExecutors(t *testing.T) {
+	f, _ := os.Open("fixture/executors.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	if conf.GetExecutor("probe") == nil {
+		t.Fatal("expected an executor")
+	}
+
+	if satisfied, _ := conf.GetCondition("healthy").GetAndWaitChange(); !satisfied {
+		t.Error("expected the healthy condition to be satisfied")
+	}
+
+	if satisfied, _ := conf.GetCondition("unhealthy").GetAndWaitChange(); satisfied {
+		t.Error("expected the unhealthy condition not to be satisfied")
+	}
+}
+
+func TestLoadInvalidExecutors(t *testing.T) {
+	for _, fixture := range []string{
+		"fixture/invalid-executors-no-name.yaml",
+		"fixture/invalid-executors-duplicate.yaml",
+		"fixture/invalid-executors-unknown.yaml",
+	} {
+		t.Run(fixture, func(t *testing.T) {
+			f, _ := os.Open(fixture)
+			defer f.Close()
+
+			if _, err := Load(f); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadSetCondition(t *testing.T) {
+	f, _ := os.Open("fixture/set-condition.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go conf.Watch(ctx)
+
+	conf.GetCondition("request").(conditional.Settable).Set(true)
+
+	select {
+	case <-conf.GetCondition("busy").Wait(true):
+	case <-time.After(time.Second):
+		t.Fatal("expected the busy condition to be set")
+	}
+
+	select {
+	case <-conf.GetCondition("request").Wait(false):
+	case <-time.After(time.Second):
+		t.Error("expected the request condition to be unset")
+	}
+}
+
+func TestLoadInvalidSetCondition(t *testing.T) {
+	for _, fixture := range []string{
+		"fixture/invalid-set-condition-unknown.yaml",
+		"fixture/invalid-set-condition-unsettable.yaml",
+	} {
+		t.Run(fixture, func(t *testing.T) {
+			f, _ := os.Open(fixture)
+			defer f.Close()
+
+			if _, err := Load(f); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadScheduler(t *testing.T) {
+	f, _ := os.Open("fixture/scheduler.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if stats := conf.Scheduler().Stats(); stats.Schedules != 1 {
+		t.Errorf("expected 1 schedule, got %d", stats.Schedules)
+	}
+
+	conf.Close()
+
+	if stats := conf.Scheduler().Stats(); stats.Schedules != 0 {
+		t.Errorf("expected no schedule after close, got %d", stats.Schedules)
+	}
+}
+
+func TestLoadInvalidScheduler(t *testing.T) {
+	for _, fixture := range []string{
+		"fixture/invalid-scheduler-jitter.yaml",
+		"fixture/invalid-scheduler-concurrency.yaml",
+	} {
+		t.Run(fixture, func(t *testing.T) {
+			f, _ := os.Open(fixture)
+			defer f.Close()
+
+			if _, err := Load(f); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadInvalidVariables(t *testing.T) {
+	for _, fixture := range []string{
+		"fixture/invalid-variables.yaml",
+		"fixture/invalid-variables-no-name.yaml",
+		"fixture/invalid-variables-duplicate.yaml",
+	} {
+		t.Run(fixture, func(t *testing.T) {
+			f, _ := os.Open(fixture)
+			defer f.Close()
+
+			if _, err := Load(f); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
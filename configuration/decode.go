@@ -12,10 +12,20 @@ func (c *configurationImpl) decode(m interface{}, rawVal interface{}) error {
 			mapstructure.StringToTimeDurationHookFunc(),
 			stringToTimeHookFunc(time.Local),
 			stringToFrequencyFunc(),
-			mapToExecutor(),
+			stringToComparisonOperatorFunc(),
+			stringToUnknownPolicyFunc(),
+			stringToErrorPolicyFunc(),
+			stringToConcurrencyPolicyFunc(),
+			stringToInitialPolicyFunc(),
+			c.mapToExecutor(),
+			c.stringToExecutor(),
+			c.mapToNumericExecutor(),
 			c.mapToAction(),
+			c.sliceToAction(),
 			c.mapToCondition(),
 			c.stringToCondition(),
+			// May return nil, and must thus be the last hook.
+			boolToTLSParamsFunc(),
 		),
 		Result: rawVal,
 	})
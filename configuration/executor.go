@@ -1,8 +1,14 @@
 package configuration
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"net"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/intelux/gotomatic/executor"
@@ -10,22 +16,407 @@ import (
 )
 
 type executorDecl struct {
+	Name    string
 	Type    string
 	Timeout time.Duration
 }
 
+type combinatorExecutorParams struct {
+	Executors []executor.Executor
+	Executor  executor.Executor
+	TTL       time.Duration
+}
+
 type commandExecutorParams struct {
-	Command string
-	Args    []string
+	Command        string
+	Args           []string
+	SuccessCodes   []int `mapstructure:"success-codes"`
+	UnknownCodes   []int `mapstructure:"unknown-codes"`
+	Stdout         string
+	StdoutContains string `mapstructure:"stdout-contains"`
+	Stderr         string
+	StderrContains string `mapstructure:"stderr-contains"`
+	Dir            string
+	Env            map[string]string
+	Stdin          string
+	Shell          bool
+}
+
+func outputPattern(pattern string, contains string) (*regexp.Regexp, error) {
+	if pattern != "" && contains != "" {
+		return nil, errors.New("a pattern and a substring cannot be specified at the same time")
+	}
+
+	if contains != "" {
+		pattern = regexp.QuoteMeta(contains)
+	}
+
+	if pattern == "" {
+		return nil, nil
+	}
+
+	return regexp.Compile(pattern)
+}
+
+func (p commandExecutorParams) options() ([]executor.CommandOption, error) {
+	var options []executor.CommandOption
+
+	if len(p.SuccessCodes) > 0 {
+		options = append(options, executor.CommandSuccessCodesOption{Codes: p.SuccessCodes})
+	}
+
+	if len(p.UnknownCodes) > 0 {
+		options = append(options, executor.CommandUnknownCodesOption{Codes: p.UnknownCodes})
+	}
+
+	stdout, err := outputPattern(p.Stdout, p.StdoutContains)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stdout != nil {
+		options = append(options, executor.CommandStdoutMatchOption{Pattern: stdout})
+	}
+
+	stderr, err := outputPattern(p.Stderr, p.StderrContains)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stderr != nil {
+		options = append(options, executor.CommandStderrMatchOption{Pattern: stderr})
+	}
+
+	if p.Dir != "" {
+		options = append(options, executor.CommandDirOption{Dir: p.Dir})
+	}
+
+	if len(p.Env) > 0 {
+		var env []string
+
+		for name, value := range p.Env {
+			env = append(env, name+"="+value)
+		}
+
+		sort.Strings(env)
+		options = append(options, executor.CommandEnvOption{Env: env})
+	}
+
+	if p.Stdin != "" {
+		options = append(options, executor.CommandStdinOption{Input: []byte(p.Stdin)})
+	}
+
+	if p.Shell {
+		options = append(options, executor.CommandShellOption{})
+	}
+
+	return options, nil
 }
 
 type httpExecutorParams struct {
-	Method      string
-	URL         string
-	StatusCodes []int
+	Method          string
+	URL             string
+	StatusCodes     []int `mapstructure:"status-codes"`
+	Body            string
+	Headers         map[string]string
+	BodyPattern     string `mapstructure:"body-pattern"`
+	JSON            []jsonAssertionParams
+	ResponseHeaders map[string]string `mapstructure:"response-headers"`
+	MaxLatency      time.Duration     `mapstructure:"max-latency"`
+	TLS             *tlsParams
+}
+
+type jsonAssertionParams struct {
+	Path     string
+	Operator executor.ComparisonOperator
+	Value    interface{}
+}
+
+func (p httpExecutorParams) options() ([]executor.HTTPOption, error) {
+	var options []executor.HTTPOption
+
+	if p.Body != "" {
+		options = append(options, executor.HTTPBodyOption{Body: []byte(p.Body)})
+	}
+
+	for name, value := range p.Headers {
+		options = append(options, executor.HTTPHeaderOption{Name: name, Value: value})
+	}
+
+	if p.BodyPattern != "" {
+		pattern, err := regexp.Compile(p.BodyPattern)
+
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, executor.HTTPBodyMatchOption{Pattern: pattern})
+	}
+
+	for _, assertion := range p.JSON {
+		if assertion.Path == "" {
+			return nil, errors.New("a path is mandatory for JSON assertions")
+		}
+
+		if assertion.Operator == nil {
+			assertion.Operator = executor.OperatorEqual
+		}
+
+		options = append(options, executor.HTTPJSONOption{Assertion: executor.JSONAssertion{
+			Path:     assertion.Path,
+			Operator: assertion.Operator,
+			Value:    assertion.Value,
+		}})
+	}
+
+	for name, value := range p.ResponseHeaders {
+		option := executor.HTTPResponseHeaderOption{Name: name}
+
+		if value != "" {
+			pattern, err := regexp.Compile(value)
+
+			if err != nil {
+				return nil, err
+			}
+
+			option.Pattern = pattern
+		}
+
+		options = append(options, option)
+	}
+
+	if p.MaxLatency > 0 {
+		options = append(options, executor.HTTPMaxLatencyOption{Latency: p.MaxLatency})
+	}
+
+	if p.TLS != nil {
+		config, err := p.TLS.config()
+
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, executor.HTTPTLSOption{Config: config})
+
+		if p.TLS.MinValidity > 0 {
+			options = append(options, executor.HTTPCertificateValidityOption{Validity: p.TLS.MinValidity})
+		}
+	}
+
+	return options, nil
+}
+
+type tcpExecutorParams struct {
+	Network string
+	Address string
+	TLS     *tlsParams
+	Send    string
+	Expect  string
 }
 
-func mapToExecutor() mapstructure.DecodeHookFunc {
+type processExecutorParams struct {
+	Name    string
+	PidFile string `mapstructure:"pidfile"`
+}
+
+type systemdExecutorParams struct {
+	Unit   string
+	User   bool
+	States []string
+}
+
+type dnsExecutorParams struct {
+	Host   string
+	Server string
+	Record string
+	Expect []string
+}
+
+func (p dnsExecutorParams) options() ([]executor.DNSOption, error) {
+	record := strings.ToUpper(p.Record)
+	supported := false
+
+	for _, recordType := range executor.DNSRecordTypes {
+		if record == recordType {
+			supported = true
+		}
+	}
+
+	if !supported {
+		return nil, fmt.Errorf("unsupported DNS record type \"%s\"", p.Record)
+	}
+
+	options := []executor.DNSOption{
+		executor.DNSRecordTypeOption{Type: record},
+	}
+
+	if p.Server != "" {
+		if _, _, err := net.SplitHostPort(p.Server); err != nil {
+			return nil, err
+		}
+
+		options = append(options, executor.DNSServerOption{Address: p.Server})
+	}
+
+	if len(p.Expect) > 0 {
+		options = append(options, executor.DNSExpectOption{Values: p.Expect})
+	}
+
+	return options, nil
+}
+
+type prometheusExecutorParams struct {
+	URL      string
+	Query    string
+	Operator executor.ComparisonOperator
+	Value    float64
+	Match    string
+	Headers  map[string]string
+	TLS      *tlsParams
+}
+
+func (c *configurationImpl) decodePrometheusExecutorParams(data interface{}) (prometheusExecutorParams, error) {
+	params := prometheusExecutorParams{
+		Operator: executor.OperatorGreater,
+		Match:    "any",
+	}
+
+	if err := c.decode(data, &params); err != nil {
+		return params, err
+	}
+
+	if params.URL == "" {
+		return params, errors.New("a URL is mandatory for that executor type")
+	}
+
+	if params.Query == "" {
+		return params, errors.New("a query is mandatory for that executor type")
+	}
+
+	return params, nil
+}
+
+func (p prometheusExecutorParams) options() ([]executor.PrometheusOption, error) {
+	var options []executor.PrometheusOption
+
+	for name, value := range p.Headers {
+		options = append(options, executor.PrometheusHeaderOption{Name: name, Value: value})
+	}
+
+	if p.TLS != nil {
+		config, err := p.TLS.config()
+
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, executor.PrometheusTLSOption{Config: config})
+	}
+
+	switch p.Match {
+	case "any":
+	case "all":
+		options = append(options, executor.PrometheusMatchAllOption{})
+	default:
+		return nil, fmt.Errorf("unknown match policy \"%s\"", p.Match)
+	}
+
+	return options, nil
+}
+
+type sqlExecutorParams struct {
+	Driver   string
+	DSN      string
+	Query    string
+	Args     []interface{}
+	Operator executor.ComparisonOperator
+	Value    float64
+}
+
+func (c *configurationImpl) decodeSQLExecutorParams(data interface{}) (sqlExecutorParams, error) {
+	var params sqlExecutorParams
+
+	if err := c.decode(data, &params); err != nil {
+		return params, err
+	}
+
+	if params.Query == "" {
+		return params, errors.New("a query is mandatory for that executor type")
+	}
+
+	for _, driver := range sql.Drivers() {
+		if driver == params.Driver {
+			return params, nil
+		}
+	}
+
+	return params, fmt.Errorf("unknown SQL driver \"%s\"", params.Driver)
+}
+
+// openSQLDatabase opens a database that is closed along with the
+// configuration.
+func (c *configurationImpl) openSQLDatabase(driver string, dsn string) (*sql.DB, error) {
+	db, err := executor.OpenSQLDatabase(driver, dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.databases = append(c.databases, db)
+
+	return db, nil
+}
+
+func (p sqlExecutorParams) options() []executor.SQLOption {
+	var options []executor.SQLOption
+
+	if len(p.Args) > 0 {
+		options = append(options, executor.SQLArgsOption{Args: p.Args})
+	}
+
+	if p.Operator != nil {
+		options = append(options, executor.SQLComparisonOption{Comparison: executor.Comparison{
+			Operator:  p.Operator,
+			Reference: p.Value,
+		}})
+	}
+
+	return options
+}
+
+type fileExecutorParams struct {
+	Path     string
+	Within   time.Duration
+	Operator executor.ComparisonOperator
+	Value    float64
+}
+
+func (p fileExecutorParams) comparison() executor.Comparison {
+	return executor.Comparison{
+		Operator:  p.Operator,
+		Reference: p.Value,
+	}
+}
+
+func (c *configurationImpl) decodeFileExecutorParams(data interface{}) (fileExecutorParams, error) {
+	params := fileExecutorParams{
+		Operator: executor.OperatorGreater,
+	}
+
+	if err := c.decode(data, &params); err != nil {
+		return params, err
+	}
+
+	if params.Path == "" {
+		return params, errors.New("a path is mandatory for that executor type")
+	}
+
+	return params, nil
+}
+
+func (c *configurationImpl) mapToExecutor() mapstructure.DecodeHookFunc {
 	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
 		if f.Kind() != reflect.Map {
 			return data, nil
@@ -39,7 +430,312 @@ func mapToExecutor() mapstructure.DecodeHookFunc {
 			Timeout: time.Second,
 		}
 
-		err := mapstructure.Decode(data, &declaration)
+		err := c.decode(data, &declaration)
+
+		if err != nil {
+			return data, err
+		}
+
+		result, err := c.newExecutor(declaration, data)
+
+		if err != nil {
+			return data, err
+		}
+
+		if declaration.Name != "" {
+			if err := c.AddExecutor(declaration.Name, result); err != nil {
+				return data, err
+			}
+		}
+
+		return result, nil
+	}
+}
+
+func (c *configurationImpl) stringToExecutor() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf((*executor.Executor)(nil)).Elem() {
+			return data, nil
+		}
+
+		name := data.(string)
+		result := c.GetExecutor(name)
+
+		if result == nil {
+			return nil, fmt.Errorf("no executor found with the name \"%s\"", name)
+		}
+
+		return result, nil
+	}
+}
+
+func (c *configurationImpl) newExecutor(declaration executorDecl, data interface{}) (executor.Executor, error) {
+	switch declaration.Type {
+	case "cmd":
+		var params commandExecutorParams
+
+		err := c.decode(data, &params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		options, err := params.options()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.CommandExecutorWithOptions(params.Command, params.Args, options...), nil
+	case "http":
+		params := httpExecutorParams{
+			Method: "GET",
+			StatusCodes: []int{
+				200,
+				201,
+			},
+		}
+
+		err := c.decode(data, &params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		options, err := params.options()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.HTTPExecutor(params.Method, params.URL, params.StatusCodes, declaration.Timeout, options...), nil
+	case "tcp":
+		params := tcpExecutorParams{
+			Network: "tcp",
+		}
+
+		err := c.decode(data, &params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if params.Address == "" {
+			return nil, errors.New("an address is mandatory for that executor type")
+		}
+
+		var options []executor.DialOption
+
+		if params.TLS != nil {
+			config, err := params.TLS.config()
+
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, executor.DialTLSOption{Config: config})
+
+			if params.TLS.MinValidity > 0 {
+				options = append(options, executor.DialCertificateValidityOption{Validity: params.TLS.MinValidity})
+			}
+		}
+
+		if params.Send != "" {
+			options = append(options, executor.DialSendOption{Data: []byte(params.Send)})
+		}
+
+		if params.Expect != "" {
+			pattern, err := regexp.Compile(params.Expect)
+
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, executor.DialExpectOption{Pattern: pattern})
+		}
+
+		return executor.DialExecutor(params.Network, params.Address, options...), nil
+	case "process":
+		var params processExecutorParams
+
+		if err := c.decode(data, &params); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case params.Name != "" && params.PidFile != "":
+			return nil, errors.New("a name and a pidfile cannot be specified at the same time")
+		case params.Name != "":
+			return executor.ProcessExecutor(params.Name), nil
+		case params.PidFile != "":
+			return executor.PidFileExecutor(params.PidFile), nil
+		}
+
+		return nil, errors.New("a name or a pidfile is mandatory for that executor type")
+	case "systemd":
+		var params systemdExecutorParams
+
+		if err := c.decode(data, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Unit == "" {
+			return nil, errors.New("a unit is mandatory for that executor type")
+		}
+
+		backend := executor.SystemctlBackend{User: params.User}
+
+		return executor.SystemdExecutor(backend, params.Unit, params.States...), nil
+	case "dns":
+		params := dnsExecutorParams{
+			Record: "A",
+		}
+
+		if err := c.decode(data, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Host == "" {
+			return nil, errors.New("a host is mandatory for that executor type")
+		}
+
+		options, err := params.options()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.DNSExecutor(params.Host, options...), nil
+	case "prometheus":
+		params, err := c.decodePrometheusExecutorParams(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		options, err := params.options()
+
+		if err != nil {
+			return nil, err
+		}
+
+		comparison := executor.Comparison{
+			Operator:  params.Operator,
+			Reference: params.Value,
+		}
+
+		return executor.PrometheusExecutor(params.URL, params.Query, comparison, options...), nil
+	case "sql":
+		params, err := c.decodeSQLExecutorParams(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		db, err := c.openSQLDatabase(params.Driver, params.DSN)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.SQLDatabaseExecutor(db, params.Query, params.options()...), nil
+	case "all", "any":
+		var params combinatorExecutorParams
+
+		if err := c.decode(data, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.Executors) == 0 {
+			return nil, errors.New("executors are mandatory for that executor type")
+		}
+
+		if declaration.Type == "all" {
+			return executor.All(params.Executors...), nil
+		}
+
+		return executor.Any(params.Executors...), nil
+	case "not", "timeout", "cached":
+		var params combinatorExecutorParams
+
+		if err := c.decode(data, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Executor == nil {
+			return nil, errors.New("an executor is mandatory for that executor type")
+		}
+
+		switch declaration.Type {
+		case "not":
+			return executor.Not(params.Executor), nil
+		case "timeout":
+			return executor.WithTimeout(declaration.Timeout, params.Executor), nil
+		}
+
+		if params.TTL <= 0 {
+			return nil, errors.New("a positive ttl is mandatory for that executor type")
+		}
+
+		return executor.Cached(params.TTL, params.Executor), nil
+	case "file-exists":
+		params, err := c.decodeFileExecutorParams(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.FileExistsExecutor(params.Path), nil
+	case "file-modified":
+		params, err := c.decodeFileExecutorParams(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if params.Within <= 0 {
+			return nil, errors.New("a positive duration is mandatory for that executor type")
+		}
+
+		return executor.FileModifiedExecutor(params.Path, params.Within), nil
+	case "dir-entries":
+		params, err := c.decodeFileExecutorParams(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.ThresholdExecutor(executor.DirEntriesNumericExecutor(params.Path), params.comparison()), nil
+	case "free-space":
+		params, err := c.decodeFileExecutorParams(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return executor.ThresholdExecutor(executor.FreeSpaceNumericExecutor(params.Path), params.comparison()), nil
+	}
+
+	return nil, fmt.Errorf("unknown command type \"%s\"", declaration.Type)
+}
+
+func (c *configurationImpl) mapToNumericExecutor() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.Map {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf((*executor.NumericExecutor)(nil)).Elem() {
+			return data, nil
+		}
+
+		var declaration executorDecl
+
+		err := c.decode(data, &declaration)
 
 		if err != nil {
 			return data, err
@@ -49,31 +745,59 @@ func mapToExecutor() mapstructure.DecodeHookFunc {
 		case "cmd":
 			var params commandExecutorParams
 
-			err := mapstructure.Decode(data, &params)
+			err := c.decode(data, &params)
 
 			if err != nil {
 				return data, err
 			}
 
-			return executor.CommandExecutor(params.Command, params.Args...), nil
-		case "http":
-			params := httpExecutorParams{
-				Method: "GET",
-				StatusCodes: []int{
-					200,
-					201,
-				},
+			return executor.CommandNumericExecutor(params.Command, params.Args...), nil
+		case "prometheus":
+			params, err := c.decodePrometheusExecutorParams(data)
+
+			if err != nil {
+				return data, err
+			}
+
+			options, err := params.options()
+
+			if err != nil {
+				return data, err
+			}
+
+			return executor.PrometheusNumericExecutor(params.URL, params.Query, options...), nil
+		case "sql":
+			params, err := c.decodeSQLExecutorParams(data)
+
+			if err != nil {
+				return data, err
+			}
+
+			db, err := c.openSQLDatabase(params.Driver, params.DSN)
+
+			if err != nil {
+				return data, err
+			}
+
+			return executor.SQLDatabaseNumericExecutor(db, params.Query, params.options()...), nil
+		case "dir-entries":
+			params, err := c.decodeFileExecutorParams(data)
+
+			if err != nil {
+				return data, err
 			}
 
-			err := mapstructure.Decode(data, &params)
+			return executor.DirEntriesNumericExecutor(params.Path), nil
+		case "free-space":
+			params, err := c.decodeFileExecutorParams(data)
 
 			if err != nil {
 				return data, err
 			}
 
-			return executor.HTTPExecutor(params.Method, params.URL, params.StatusCodes, declaration.Timeout), nil
+			return executor.FreeSpaceNumericExecutor(params.Path), nil
 		}
 
-		return data, fmt.Errorf("unknown command type \"%s\"", declaration.Type)
+		return data, fmt.Errorf("unknown numeric command type \"%s\"", declaration.Type)
 	}
 }
@@ -0,0 +1,155 @@
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/intelux/gotomatic/executor"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestHTTPExecutorParams(t *testing.T) {
+	configuration := newConfigurationImpl()
+	defer configuration.Close()
+
+	data := readYAMLFixture("fixture/cut-off-condition-http-assertions.yaml")
+	var decl struct {
+		Executor httpExecutorParams
+	}
+
+	if err := configuration.decode(data, &decl); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	params := decl.Executor
+
+	if len(params.StatusCodes) != 1 || params.StatusCodes[0] != 200 {
+		t.Errorf("expected status codes [200], got %v", params.StatusCodes)
+	}
+
+	options, err := params.options()
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if len(options) != 9 {
+		t.Errorf("expected 9 options, got %d", len(options))
+	}
+}
+
+func TestFileExecutorParams(t *testing.T) {
+	configuration := newConfigurationImpl()
+	defer configuration.Close()
+
+	params, err := configuration.decodeFileExecutorParams(readYAMLFixture("fixture/cut-off-condition-dir-entries.yaml").(map[interface{}]interface{})["executor"])
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if params.Path != "/tmp" {
+		t.Errorf("expected \"/tmp\" as the path, got \"%s\"", params.Path)
+	}
+
+	if comparison := params.comparison(); comparison.Operator != executor.OperatorLess || comparison.Reference != 100 {
+		t.Errorf("expected \"< 100\", got \"%s %v\"", comparison.Operator, comparison.Reference)
+	}
+
+	params, err = configuration.decodeFileExecutorParams(map[string]interface{}{"path": "/"})
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if params.Operator != executor.OperatorGreater {
+		t.Errorf("expected \">\" as the default operator, got \"%s\"", params.Operator)
+	}
+}
+
+func TestCommandExecutorParams(t *testing.T) {
+	configuration := newConfigurationImpl()
+	defer configuration.Close()
+
+	data := readYAMLFixture("fixture/cut-off-condition-cmd-options.yaml")
+	var decl struct {
+		Executor commandExecutorParams
+	}
+
+	if err := configuration.decode(data, &decl); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	params := decl.Executor
+
+	if len(params.SuccessCodes) != 2 || params.SuccessCodes[1] != 2 {
+		t.Errorf("expected success codes [0 2], got %v", params.SuccessCodes)
+	}
+
+	if params.Stdin != "hay\nneedle\n" {
+		t.Errorf("expected a standard input, got %q", params.Stdin)
+	}
+
+	options, err := params.options()
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if len(options) != 8 {
+		t.Errorf("expected 8 options, got %d", len(options))
+	}
+
+	value := executor.CommandExecutorWithOptions(params.Command, params.Args, options...)(context.Background())
+
+	if !value.OK {
+		t.Errorf("expected true, got %s (%q)", value, value.Output)
+	}
+}
+
+func TestSQLExecutorParams(t *testing.T) {
+	configuration := newConfigurationImpl()
+	defer configuration.Close()
+
+	data := readYAMLFixture("fixture/cut-off-condition-sql.yaml").(map[interface{}]interface{})["executor"]
+	params, err := configuration.decodeSQLExecutorParams(data)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if len(params.Args) != 2 || params.Args[0] != "pending" || params.Args[1] != 3 {
+		t.Errorf("expected [pending 3] as arguments, got %v", params.Args)
+	}
+
+	if options := params.options(); len(options) != 2 {
+		t.Errorf("expected 2 options, got %d", len(options))
+	}
+}
+
+func TestSQLExecutor(t *testing.T) {
+	configuration := newConfigurationImpl()
+
+	var decl struct {
+		Executor executor.Executor
+	}
+
+	if err := configuration.decode(readYAMLFixture("fixture/cut-off-condition-sql.yaml"), &decl); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if value := decl.Executor(context.Background()); !value.OK || value.Output != "2" {
+		t.Errorf("expected true with 2 pending jobs, got %s (%q)", value, value.Output)
+	}
+
+	if len(configuration.databases) != 1 {
+		t.Fatalf("expected 1 database, got %d", len(configuration.databases))
+	}
+
+	db := configuration.databases[0]
+	configuration.Close()
+
+	if err := db.Ping(); err == nil {
+		t.Error("expected the database to be closed")
+	}
+}
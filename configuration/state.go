@@ -0,0 +1,43 @@
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/intelux/gotomatic/conditional"
+	"github.com/mitchellh/mapstructure"
+)
+
+func parseUnknownPolicy(s string) (conditional.UnknownPolicy, error) {
+	switch s {
+	case "hold":
+		return conditional.UnknownHold, nil
+	case "false":
+		return conditional.UnknownFalse, nil
+	case "true":
+		return conditional.UnknownTrue, nil
+	case "propagate":
+		return conditional.UnknownPropagate, nil
+	}
+
+	return conditional.UnknownHold, fmt.Errorf("unknown policy \"%s\"", s)
+}
+
+// stringToUnknownPolicyFunc transforms a string or a boolean into an unknown
+// policy.
+func stringToUnknownPolicyFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if t != reflect.TypeOf(conditional.UnknownHold) {
+			return data, nil
+		}
+
+		switch f.Kind() {
+		case reflect.String:
+			return parseUnknownPolicy(data.(string))
+		case reflect.Bool:
+			return parseUnknownPolicy(fmt.Sprintf("%t", data))
+		}
+
+		return data, nil
+	}
+}
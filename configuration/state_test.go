@@ -0,0 +1,62 @@
+package configuration
+
+import (
+	"os"
+	"testing"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func TestParseUnknownPolicy(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected conditional.UnknownPolicy
+	}{
+		{"hold", conditional.UnknownHold},
+		{"false", conditional.UnknownFalse},
+		{"true", conditional.UnknownTrue},
+		{"propagate", conditional.UnknownPropagate},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			value, err := parseUnknownPolicy(testCase.Value)
+
+			if err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if value != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, value)
+			}
+		})
+	}
+
+	if _, err := parseUnknownPolicy("maybe"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestLoadTriggerUnknown(t *testing.T) {
+	f, _ := os.Open("fixture/trigger-unknown.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	if policy := conf.(*configurationImpl).triggers[0].Unknown; policy != conditional.UnknownTrue {
+		t.Errorf("expected %s, got %s", conditional.UnknownTrue, policy)
+	}
+
+	f, _ = os.Open("fixture/invalid-trigger-unknown.yaml")
+	defer f.Close()
+
+	if _, err = Load(f); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,105 @@
+package configuration
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+type tlsParams struct {
+	ServerName         string        `mapstructure:"server-name"`
+	InsecureSkipVerify bool          `mapstructure:"insecure-skip-verify"`
+	CA                 string        `mapstructure:"ca"`
+	Cert               string        `mapstructure:"cert"`
+	Key                string        `mapstructure:"key"`
+	MinVersion         interface{}   `mapstructure:"min-version"`
+	MinValidity        time.Duration `mapstructure:"min-validity"`
+}
+
+func (p tlsParams) config() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         p.ServerName,
+		InsecureSkipVerify: p.InsecureSkipVerify,
+	}
+
+	if p.CA != "" {
+		data, err := ioutil.ReadFile(p.CA)
+
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+
+		if !config.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificate found in \"%s\"", p.CA)
+		}
+	}
+
+	if p.Cert != "" || p.Key != "" {
+		if p.Cert == "" || p.Key == "" {
+			return nil, errors.New("a client certificate requires both a certificate and a key")
+		}
+
+		certificate, err := tls.LoadX509KeyPair(p.Cert, p.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if p.MinVersion != nil {
+		version, err := parseTLSVersion(fmt.Sprint(p.MinVersion))
+
+		if err != nil {
+			return nil, err
+		}
+
+		config.MinVersion = version
+	}
+
+	return config, nil
+}
+
+func parseTLSVersion(s string) (uint16, error) {
+	switch s {
+	case "1", "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+
+	return 0, fmt.Errorf("unknown TLS version \"%s\"", s)
+}
+
+// boolToTLSParamsFunc allows TLS parameters to be specified as a boolean,
+// with their default values.
+func boolToTLSParamsFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.Bool {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf((*tlsParams)(nil)) {
+			return data, nil
+		}
+
+		if !data.(bool) {
+			return nil, nil
+		}
+
+		return map[string]interface{}{}, nil
+	}
+}
@@ -0,0 +1,94 @@
+package configuration
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+)
+
+func TestTLSParams(t *testing.T) {
+	configuration := newConfigurationImpl()
+	defer configuration.Close()
+
+	data := readYAMLFixture("fixture/cut-off-condition-http-tls.yaml")
+	var decl struct {
+		Executor httpExecutorParams
+	}
+
+	if err := configuration.decode(data, &decl); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	params := decl.Executor.TLS
+
+	if params == nil {
+		t.Fatal("expected TLS parameters")
+	}
+
+	if params.MinValidity != 720*time.Hour {
+		t.Errorf("expected a minimum validity of 720h, got %s", params.MinValidity)
+	}
+
+	config, err := params.config()
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if config.ServerName != "example.com" {
+		t.Errorf("expected \"example.com\" as the server name, got \"%s\"", config.ServerName)
+	}
+
+	if config.RootCAs == nil {
+		t.Error("expected a CA pool")
+	}
+
+	if len(config.Certificates) != 1 {
+		t.Errorf("expected one client certificate, got %d", len(config.Certificates))
+	}
+
+	if config.MinVersion != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2 as the minimum version, got %x", config.MinVersion)
+	}
+
+	options, err := decl.Executor.options()
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if len(options) != 2 {
+		t.Errorf("expected 2 options, got %d", len(options))
+	}
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected uint16
+	}{
+		{"1", tls.VersionTLS10},
+		{"1.0", tls.VersionTLS10},
+		{"1.1", tls.VersionTLS11},
+		{"1.2", tls.VersionTLS12},
+		{"1.3", tls.VersionTLS13},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			version, err := parseTLSVersion(testCase.Value)
+
+			if err != nil {
+				t.Fatalf("expected no error but got: %s", err)
+			}
+
+			if version != testCase.Expected {
+				t.Errorf("expected %x, got %x", testCase.Expected, version)
+			}
+		})
+	}
+
+	if _, err := parseTLSVersion("2.0"); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -3,13 +3,203 @@ package configuration
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"reflect"
+	"text/template"
+	"time"
 
+	"github.com/intelux/gotomatic/conditional"
 	"github.com/intelux/gotomatic/trigger"
 	"github.com/mitchellh/mapstructure"
 )
 
+func parseErrorPolicy(s string) (trigger.ErrorPolicy, error) {
+	switch s {
+	case "fail":
+		return trigger.ErrorFail, nil
+	case "log":
+		return trigger.ErrorLog, nil
+	case "retry":
+		return trigger.ErrorRetry, nil
+	case "disable":
+		return trigger.ErrorDisable, nil
+	}
+
+	return trigger.ErrorFail, fmt.Errorf("unknown error policy \"%s\"", s)
+}
+
+// stringToErrorPolicyFunc transforms a string into an error policy.
+func stringToErrorPolicyFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf(trigger.ErrorFail) {
+			return data, nil
+		}
+
+		return parseErrorPolicy(data.(string))
+	}
+}
+
+func parseConcurrencyPolicy(s string) (trigger.ConcurrencyPolicy, error) {
+	switch s {
+	case "queue":
+		return trigger.ConcurrencyQueue, nil
+	case "cancel":
+		return trigger.ConcurrencyCancel, nil
+	case "skip":
+		return trigger.ConcurrencySkip, nil
+	case "coalesce":
+		return trigger.ConcurrencyCoalesce, nil
+	}
+
+	return trigger.ConcurrencyQueue, fmt.Errorf("unknown concurrency policy \"%s\"", s)
+}
+
+// stringToConcurrencyPolicyFunc transforms a string into a concurrency
+// policy.
+func stringToConcurrencyPolicyFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf(trigger.ConcurrencyQueue) {
+			return data, nil
+		}
+
+		return parseConcurrencyPolicy(data.(string))
+	}
+}
+
+func parseInitialPolicy(s string) (trigger.InitialPolicy, error) {
+	switch s {
+	case "fire":
+		return trigger.InitialFire, nil
+	case "skip":
+		return trigger.InitialSkip, nil
+	case "changed":
+		return trigger.InitialChanged, nil
+	}
+
+	return trigger.InitialFire, fmt.Errorf("unknown initial policy \"%s\"", s)
+}
+
+// stringToInitialPolicyFunc transforms a string into an initial policy.
+func stringToInitialPolicyFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf(trigger.InitialFire) {
+			return data, nil
+		}
+
+		return parseInitialPolicy(data.(string))
+	}
+}
+
+type retryParams struct {
+	Max        int
+	Initial    time.Duration
+	MaxDelay   time.Duration `mapstructure:"max-delay"`
+	Multiplier float64
+	Jitter     float64
+	Deadline   time.Duration
+}
+
+func (c *configurationImpl) decodeRetryPolicy(data interface{}) (trigger.RetryPolicy, error) {
+	params := retryParams{
+		Max:        trigger.DefaultRetryMax,
+		Initial:    time.Second,
+		Multiplier: trigger.DefaultRetryMultiplier,
+	}
+
+	if err := c.decode(data, &params); err != nil {
+		return trigger.RetryPolicy{}, err
+	}
+
+	if params.Max <= 0 {
+		return trigger.RetryPolicy{}, errors.New("the maximum number of attempts must be positive")
+	}
+
+	if params.Multiplier < 1 {
+		return trigger.RetryPolicy{}, fmt.Errorf("the retry multiplier must be at least 1 but was %v", params.Multiplier)
+	}
+
+	if params.Jitter < 0 || params.Jitter > 1 {
+		return trigger.RetryPolicy{}, fmt.Errorf("the retry jitter must be within [0, 1] but was %v", params.Jitter)
+	}
+
+	return trigger.RetryPolicy{
+		Max:        params.Max,
+		Initial:    params.Initial,
+		MaxDelay:   params.MaxDelay,
+		Multiplier: params.Multiplier,
+		Jitter:     params.Jitter,
+		Deadline:   params.Deadline,
+	}, nil
+}
+
+type triggerDecl struct {
+	Up          trigger.Action
+	Down        trigger.Action
+	Unknown     conditional.UnknownPolicy
+	OnError     trigger.ErrorPolicy `mapstructure:"on-error"`
+	Retry       interface{}
+	Concurrency trigger.ConcurrencyPolicy
+	Timeout     time.Duration
+	Initial     trigger.InitialPolicy
+}
+
+func (c *configurationImpl) newTrigger(declaration triggerDecl) (trigger.Trigger, error) {
+	tr := trigger.Trigger{
+		Up:          declaration.Up,
+		Down:        declaration.Down,
+		Unknown:     declaration.Unknown,
+		OnError:     declaration.OnError,
+		Concurrency: declaration.Concurrency,
+		Timeout:     declaration.Timeout,
+		Initial:     declaration.Initial,
+	}
+
+	if declaration.Retry != nil {
+		policy, err := c.decodeRetryPolicy(declaration.Retry)
+
+		if err != nil {
+			return tr, err
+		}
+
+		tr.Retry = policy
+	}
+
+	return tr, nil
+}
+
+func (c *configurationImpl) sliceToAction() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.Slice {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf((*trigger.Action)(nil)).Elem() {
+			return data, nil
+		}
+
+		var actions []trigger.Action
+
+		if err := c.decode(data, &actions); err != nil {
+			return data, err
+		}
+
+		return trigger.Sequence(actions...), nil
+	}
+}
+
 func (c *configurationImpl) mapToAction() mapstructure.DecodeHookFunc {
 	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
 		if f.Kind() != reflect.Map {
@@ -21,7 +211,9 @@ func (c *configurationImpl) mapToAction() mapstructure.DecodeHookFunc {
 		}
 
 		declaration := struct {
-			Type string
+			Type    string
+			Timeout time.Duration
+			Retry   map[string]interface{}
 		}{}
 
 		err := c.decode(data, &declaration)
@@ -50,18 +242,199 @@ func (c *configurationImpl) mapToAction() mapstructure.DecodeHookFunc {
 				return data, errors.New("a command is mandatory for that action type")
 			}
 
-			env := os.Environ()
+			cmd, err := trigger.NewTemplate("command", params.Command)
+
+			if err != nil {
+				return data, err
+			}
+
+			args := make([]*template.Template, len(params.Args))
+
+			for i, arg := range params.Args {
+				if args[i], err = trigger.NewTemplate(fmt.Sprintf("args[%d]", i), arg); err != nil {
+					return data, err
+				}
+			}
+
+			env := make(map[string]*template.Template, len(params.Env))
 
 			for key, value := range params.Env {
-				env = append(env, fmt.Sprintf("%s=%s", key, value))
+				if env[key], err = trigger.NewTemplate(key, value); err != nil {
+					return data, err
+				}
+			}
+
+			action = trigger.NewCommandTemplateAction(cmd, args, os.Environ(), env)
+		case "http":
+			params := struct {
+				Method        string
+				URL           string
+				Headers       map[string]string
+				Body          string
+				StatusCodes   []int         `mapstructure:"status-codes"`
+				ClientTimeout time.Duration `mapstructure:"client-timeout"`
+			}{
+				Method:        "POST",
+				ClientTimeout: 10 * time.Second,
+			}
+
+			// An action timeout bounds the request through its context, so
+			// that it gets reported as a trigger.TimeoutError.
+			if declaration.Timeout > 0 {
+				params.ClientTimeout = 0
+			}
+
+			err := c.decode(data, &params)
+
+			if err != nil {
+				return data, err
+			}
+
+			if params.URL == "" {
+				return data, errors.New("a URL is mandatory for that action type")
+			}
+
+			url, err := trigger.NewTemplate("url", params.URL)
+
+			if err != nil {
+				return data, err
 			}
 
-			action = trigger.NewCommandAction(params.Command, params.Args, env)
+			header := make(http.Header)
+
+			for name, value := range params.Headers {
+				header.Set(name, value)
+			}
+
+			var body *template.Template
+
+			if params.Body != "" {
+				if body, err = trigger.NewTemplate("body", params.Body); err != nil {
+					return data, err
+				}
+			}
+
+			action = trigger.NewHTTPTemplateAction(params.Method, url, header, body, params.StatusCodes, params.ClientTimeout)
+		case "set":
+			params := struct {
+				Condition string
+				Mode      string
+				State     bool
+				Duration  time.Duration
+			}{
+				Mode:  "set",
+				State: true,
+			}
+
+			err := c.decode(data, &params)
+
+			if err != nil {
+				return data, err
+			}
+
+			if params.Condition == "" {
+				return data, errors.New("a condition is mandatory for that action type")
+			}
+
+			switch params.Mode {
+			case "set":
+				action = trigger.NewSetConditionAction(c, params.Condition, params.State)
+			case "toggle":
+				action = trigger.NewToggleConditionAction(c, params.Condition)
+			case "pulse":
+				if params.Duration <= 0 {
+					return data, errors.New("a positive duration is mandatory for the pulse mode")
+				}
+
+				action = trigger.NewPulseConditionAction(c, params.Condition, params.State, params.Duration)
+			default:
+				return data, fmt.Errorf("unknown set mode: %s", params.Mode)
+			}
+
+			// The condition may be declared after the action, so that
+			// conditions can set each other: it gets checked once the whole
+			// configuration is decoded.
+			c.conditionRefs = append(c.conditionRefs, params.Condition)
+		case "sequence":
+			params := struct {
+				Actions         []trigger.Action
+				ContinueOnError bool `mapstructure:"continue-on-error"`
+			}{}
 
+			err := c.decode(data, &params)
+
+			if err != nil {
+				return data, err
+			}
+
+			if len(params.Actions) == 0 {
+				return data, errors.New("at least one action is mandatory for that action type")
+			}
+
+			if params.ContinueOnError {
+				action = trigger.SequenceAll(params.Actions...)
+			} else {
+				action = trigger.Sequence(params.Actions...)
+			}
+		case "parallel":
+			params := struct {
+				Actions  []trigger.Action
+				FailFast bool `mapstructure:"fail-fast"`
+			}{}
+
+			err := c.decode(data, &params)
+
+			if err != nil {
+				return data, err
+			}
+
+			if len(params.Actions) == 0 {
+				return data, errors.New("at least one action is mandatory for that action type")
+			}
+
+			if params.FailFast {
+				action = trigger.ParallelFailFast(params.Actions...)
+			} else {
+				action = trigger.Parallel(params.Actions...)
+			}
+		case "wait":
+			params := struct {
+				Duration time.Duration
+			}{}
+
+			err := c.decode(data, &params)
+
+			if err != nil {
+				return data, err
+			}
+
+			if params.Duration <= 0 {
+				return data, errors.New("a positive duration is mandatory for that action type")
+			}
+
+			action = trigger.Wait(params.Duration)
 		default:
 			return data, fmt.Errorf("unknown action type: %s", declaration.Type)
 		}
 
+		if declaration.Timeout < 0 {
+			return data, errors.New("an action timeout cannot be negative")
+		}
+
+		if declaration.Timeout > 0 {
+			action = trigger.Timeout(action, declaration.Timeout)
+		}
+
+		if declaration.Retry != nil {
+			policy, err := c.decodeRetryPolicy(declaration.Retry)
+
+			if err != nil {
+				return data, err
+			}
+
+			action = trigger.RetryWithPolicy(action, policy)
+		}
+
 		return action, nil
 	}
 }
@@ -1,7 +1,9 @@
 package configuration
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/intelux/gotomatic/trigger"
 )
@@ -17,6 +19,35 @@ func TestMapToAction(t *testing.T) {
 		{"fixture/action-command-invalid-params.yaml", true},
 		{"fixture/action-command-empty-command.yaml", true},
 		{"fixture/action-command.yaml", false},
+		{"fixture/action-command-invalid-template.yaml", true},
+		{"fixture/action-command-invalid-env-template.yaml", true},
+		{"fixture/action-command-template.yaml", false},
+		{"fixture/action-http-invalid-url.yaml", true},
+		{"fixture/action-http-empty-url.yaml", true},
+		{"fixture/action-http-invalid-body.yaml", true},
+		{"fixture/action-http.yaml", false},
+		{"fixture/action-set-empty-condition.yaml", true},
+		{"fixture/action-set-unknown-mode.yaml", true},
+		{"fixture/action-set-pulse-no-duration.yaml", true},
+		{"fixture/action-set.yaml", false},
+		{"fixture/action-set-toggle.yaml", false},
+		{"fixture/action-set-pulse.yaml", false},
+		{"fixture/action-list-invalid.yaml", true},
+		{"fixture/action-list.yaml", false},
+		{"fixture/action-sequence-no-actions.yaml", true},
+		{"fixture/action-sequence.yaml", false},
+		{"fixture/action-parallel-no-actions.yaml", true},
+		{"fixture/action-parallel.yaml", false},
+		{"fixture/action-wait-no-duration.yaml", true},
+		{"fixture/action-wait.yaml", false},
+		{"fixture/action-retry-invalid.yaml", true},
+		{"fixture/action-retry-invalid-max.yaml", true},
+		{"fixture/action-retry-invalid-jitter.yaml", true},
+		{"fixture/action-retry-invalid-multiplier.yaml", true},
+		{"fixture/action-retry.yaml", false},
+		{"fixture/action-timeout-negative.yaml", true},
+		{"fixture/action-timeout-invalid.yaml", true},
+		{"fixture/action-timeout.yaml", false},
 	}
 
 	for _, testCase := range testCases {
@@ -41,3 +72,219 @@ func TestMapToAction(t *testing.T) {
 		})
 	}
 }
+
+func TestParseErrorPolicy(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected trigger.ErrorPolicy
+	}{
+		{"fail", trigger.ErrorFail},
+		{"log", trigger.ErrorLog},
+		{"retry", trigger.ErrorRetry},
+		{"disable", trigger.ErrorDisable},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			value, err := parseErrorPolicy(testCase.Value)
+
+			if err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if value != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, value)
+			}
+		})
+	}
+
+	if _, err := parseErrorPolicy("ignore"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestParseConcurrencyPolicy(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected trigger.ConcurrencyPolicy
+	}{
+		{"queue", trigger.ConcurrencyQueue},
+		{"cancel", trigger.ConcurrencyCancel},
+		{"skip", trigger.ConcurrencySkip},
+		{"coalesce", trigger.ConcurrencyCoalesce},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			value, err := parseConcurrencyPolicy(testCase.Value)
+
+			if err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if value != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, value)
+			}
+		})
+	}
+
+	if _, err := parseConcurrencyPolicy("parallel"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestLoadTriggerConcurrency(t *testing.T) {
+	f, _ := os.Open("fixture/trigger-concurrency.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	if policy := conf.(*configurationImpl).triggers[0].Concurrency; policy != trigger.ConcurrencyCoalesce {
+		t.Errorf("expected %s, got %s", trigger.ConcurrencyCoalesce, policy)
+	}
+
+	f, _ = os.Open("fixture/invalid-trigger-concurrency.yaml")
+	defer f.Close()
+
+	if _, err = Load(f); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestParseInitialPolicy(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected trigger.InitialPolicy
+	}{
+		{"fire", trigger.InitialFire},
+		{"skip", trigger.InitialSkip},
+		{"changed", trigger.InitialChanged},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			value, err := parseInitialPolicy(testCase.Value)
+
+			if err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if value != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, value)
+			}
+		})
+	}
+
+	if _, err := parseInitialPolicy("always"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestLoadTriggerInitial(t *testing.T) {
+	f, _ := os.Open("fixture/trigger-initial.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	if policy := conf.(*configurationImpl).triggers[0].Initial; policy != trigger.InitialChanged {
+		t.Errorf("expected %s, got %s", trigger.InitialChanged, policy)
+	}
+
+	f, _ = os.Open("fixture/invalid-trigger-initial.yaml")
+	defer f.Close()
+
+	if _, err = Load(f); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestLoadTriggerTimeout(t *testing.T) {
+	f, _ := os.Open("fixture/trigger-timeout.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	if timeout := conf.(*configurationImpl).triggers[0].Timeout; timeout != 30*time.Second {
+		t.Errorf("expected 30s, got %s", timeout)
+	}
+}
+
+func TestLoadTriggerOnError(t *testing.T) {
+	f, _ := os.Open("fixture/trigger-on-error.yaml")
+	defer f.Close()
+
+	conf, err := Load(f)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conf.Close()
+
+	tr := conf.(*configurationImpl).triggers[0]
+
+	if tr.OnError != trigger.ErrorRetry {
+		t.Errorf("expected %s, got %s", trigger.ErrorRetry, tr.OnError)
+	}
+
+	if tr.Retry.Max != 3 || tr.Retry.Initial != 100*time.Millisecond {
+		t.Errorf("expected 3 attempts after 100ms, got %d after %s", tr.Retry.Max, tr.Retry.Initial)
+	}
+
+	f, _ = os.Open("fixture/invalid-trigger-on-error.yaml")
+	defer f.Close()
+
+	if _, err = Load(f); err == nil {
+		t.Error("expected an error")
+	}
+
+	f, _ = os.Open("fixture/invalid-trigger-retry.yaml")
+	defer f.Close()
+
+	if _, err = Load(f); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestDecodeRetryPolicy(t *testing.T) {
+	configuration := newConfigurationImpl()
+	defer configuration.Close()
+
+	policy, err := configuration.decodeRetryPolicy(map[string]interface{}{
+		"max":       5,
+		"max-delay": "1m",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	expected := trigger.RetryPolicy{
+		Max:        5,
+		Initial:    time.Second,
+		MaxDelay:   time.Minute,
+		Multiplier: trigger.DefaultRetryMultiplier,
+	}
+
+	if policy.Max != expected.Max || policy.Initial != expected.Initial || policy.MaxDelay != expected.MaxDelay || policy.Multiplier != expected.Multiplier {
+		t.Errorf("expected %+v, got %+v", expected, policy)
+	}
+}
@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// All returns an Executor that is true whenever all the specified executors
+// are true.
+//
+// Executors are called in order until one of them returns false, whose
+// result is then returned. If none returns false but some fail, the result
+// of the first failure is returned.
+func All(executors ...Executor) Executor {
+	return combine(false, executors)
+}
+
+// Any returns an Executor that is true whenever any of the specified
+// executors is true.
+//
+// Executors are called in order until one of them returns true, whose result
+// is then returned. If none returns true but some fail, the result of the
+// first failure is returned.
+func Any(executors ...Executor) Executor {
+	return combine(true, executors)
+}
+
+// combine calls the executors until one of them returns the decisive status.
+func combine(decisive bool, executors []Executor) Executor {
+	return func(ctx context.Context) Result {
+		start := time.Now()
+		result := Result{OK: !decisive}
+		failed := false
+
+		for _, executor := range executors {
+			current := executor(ctx)
+
+			if current.Known() && current.OK == decisive {
+				result = current
+				break
+			}
+
+			if !current.Known() && !failed {
+				result = current
+				failed = true
+			}
+		}
+
+		result.Latency = time.Since(start)
+
+		return result
+	}
+}
+
+// Not returns an Executor that is true whenever the specified executor is
+// false, and vice versa.
+//
+// The executor fails if the specified executor fails.
+func Not(executor Executor) Executor {
+	return func(ctx context.Context) Result {
+		result := executor(ctx)
+
+		if result.Known() {
+			result.OK = !result.OK
+		}
+
+		return result
+	}
+}
+
+// WithTimeout returns an Executor that calls the specified executor with a
+// context that expires after the specified timeout.
+func WithTimeout(timeout time.Duration, executor Executor) Executor {
+	return func(ctx context.Context) Result {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return executor(ctx)
+	}
+}
+
+type cachedExecutor struct {
+	executor Executor
+	ttl      time.Duration
+	lock     sync.Mutex
+	result   Result
+	expiry   time.Time
+}
+
+// Cached returns an Executor that calls the specified executor at most once
+// per ttl, and returns its last result otherwise.
+//
+// Concurrent calls are serialized, so that the executor can be shared among
+// several conditions without being called more often. Results obtained while
+// the caller context was done are never cached.
+func Cached(ttl time.Duration, executor Executor) Executor {
+	cached := &cachedExecutor{
+		executor: executor,
+		ttl:      ttl,
+	}
+
+	return cached.run
+}
+
+func (e *cachedExecutor) run(ctx context.Context) Result {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if time.Now().Before(e.expiry) {
+		return e.result
+	}
+
+	result := e.executor(ctx)
+
+	if ctx.Err() == nil {
+		e.result = result
+		e.expiry = time.Now().Add(e.ttl)
+	}
+
+	return result
+}
@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func resultExecutor(result Result) Executor {
+	return func(context.Context) Result { return result }
+}
+
+var (
+	trueResult    = Result{OK: true}
+	falseResult   = Result{OK: false}
+	unknownResult = Result{Err: errors.New("fail")}
+)
+
+func TestAllAndAny(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Results []Result
+		All     Result
+		Any     Result
+	}{
+		{"none", nil, trueResult, falseResult},
+		{"true", []Result{trueResult, trueResult}, trueResult, trueResult},
+		{"false", []Result{falseResult, falseResult}, falseResult, falseResult},
+		{"mixed", []Result{trueResult, falseResult}, falseResult, trueResult},
+		{"unknown-true", []Result{unknownResult, trueResult}, unknownResult, trueResult},
+		{"unknown-false", []Result{unknownResult, falseResult}, falseResult, unknownResult},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var executors []Executor
+
+			for _, result := range testCase.Results {
+				executors = append(executors, resultExecutor(result))
+			}
+
+			if result := All(executors...)(context.Background()); result.OK != testCase.All.OK || result.Known() != testCase.All.Known() {
+				t.Errorf("expected %s for all, got %s", testCase.All, result)
+			}
+
+			if result := Any(executors...)(context.Background()); result.OK != testCase.Any.OK || result.Known() != testCase.Any.Known() {
+				t.Errorf("expected %s for any, got %s", testCase.Any, result)
+			}
+		})
+	}
+}
+
+func TestAllShortCircuits(t *testing.T) {
+	called := false
+	flag := func(context.Context) Result {
+		called = true
+		return trueResult
+	}
+
+	All(FalseExecutor, flag)(context.Background())
+	Any(TrueExecutor, flag)(context.Background())
+
+	if called {
+		t.Error("expected the executors to short-circuit")
+	}
+}
+
+func TestNot(t *testing.T) {
+	if result := Not(TrueExecutor)(context.Background()); result.OK || !result.Known() {
+		t.Errorf("expected false, got %s", result)
+	}
+
+	if result := Not(FalseExecutor)(context.Background()); !result.OK {
+		t.Errorf("expected true, got %s", result)
+	}
+
+	if result := Not(resultExecutor(unknownResult))(context.Background()); result.Known() {
+		t.Errorf("expected an unknown result, got %s", result)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	result := WithTimeout(10*time.Millisecond, CommandExecutor("sleep", "1"))(context.Background())
+
+	if result.Known() {
+		t.Errorf("expected an unknown result, got %s", result)
+	}
+}
+
+func TestCached(t *testing.T) {
+	calls := 0
+	executor := Cached(time.Hour, func(ctx context.Context) Result {
+		calls++
+		return Result{OK: calls == 2, Err: ctx.Err()}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if result := executor(ctx); result.Known() {
+		t.Errorf("expected an unknown result, got %s", result)
+	}
+
+	for i := 0; i < 3; i++ {
+		if result := executor(context.Background()); !result.OK {
+			t.Errorf("expected the cached result, got %s", result)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
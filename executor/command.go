@@ -0,0 +1,226 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+// DefaultShell is the shell used by a CommandShellOption that does not
+// specify one.
+const DefaultShell = "/bin/sh"
+
+// MaxCommandOutputSize is the maximum size of the standard and error outputs
+// that a command executor matches against patterns.
+const MaxCommandOutputSize = 1 << 20
+
+// commandWaitDelay bounds the wait for the outputs of a command after its
+// context expired, in case some of its child processes keep them open.
+const commandWaitDelay = 500 * time.Millisecond
+
+type commandExecutor struct {
+	command       string
+	args          []string
+	successCodes  []int
+	unknownCodes  []int
+	stdoutPattern *regexp.Regexp
+	stderrPattern *regexp.Regexp
+	dir           string
+	env           []string
+	stdin         []byte
+	shell         string
+}
+
+// CommandOption represents an option for a command executor.
+type CommandOption interface {
+	apply(executor *commandExecutor)
+}
+
+// CommandSuccessCodesOption defines the exit codes for which a command
+// executor returns true.
+type CommandSuccessCodesOption struct {
+	Codes []int
+}
+
+func (o CommandSuccessCodesOption) apply(executor *commandExecutor) {
+	executor.successCodes = o.Codes
+}
+
+// CommandUnknownCodesOption defines the exit codes for which a command
+// executor fails, as if the command could not be run.
+type CommandUnknownCodesOption struct {
+	Codes []int
+}
+
+func (o CommandUnknownCodesOption) apply(executor *commandExecutor) {
+	executor.unknownCodes = o.Codes
+}
+
+// CommandStdoutMatchOption requires the standard output of the command run by
+// a command executor to match the specified pattern.
+type CommandStdoutMatchOption struct {
+	Pattern *regexp.Regexp
+}
+
+func (o CommandStdoutMatchOption) apply(executor *commandExecutor) {
+	executor.stdoutPattern = o.Pattern
+}
+
+// CommandStderrMatchOption requires the error output of the command run by a
+// command executor to match the specified pattern.
+type CommandStderrMatchOption struct {
+	Pattern *regexp.Regexp
+}
+
+func (o CommandStderrMatchOption) apply(executor *commandExecutor) {
+	executor.stderrPattern = o.Pattern
+}
+
+// CommandDirOption defines the working directory of the command run by a
+// command executor.
+type CommandDirOption struct {
+	Dir string
+}
+
+func (o CommandDirOption) apply(executor *commandExecutor) {
+	executor.dir = o.Dir
+}
+
+// CommandEnvOption adds environment variables, in the "key=value" form, to
+// the environment inherited by the command run by a command executor.
+type CommandEnvOption struct {
+	Env []string
+}
+
+func (o CommandEnvOption) apply(executor *commandExecutor) {
+	executor.env = append(executor.env, o.Env...)
+}
+
+// CommandStdinOption defines the standard input of the command run by a
+// command executor.
+type CommandStdinOption struct {
+	Input []byte
+}
+
+func (o CommandStdinOption) apply(executor *commandExecutor) {
+	executor.stdin = o.Input
+}
+
+// CommandShellOption makes a command executor run its command through a
+// shell, passing its arguments as positional parameters.
+//
+// If no shell is specified, DefaultShell is used.
+type CommandShellOption struct {
+	Shell string
+}
+
+func (o CommandShellOption) apply(executor *commandExecutor) {
+	executor.shell = o.Shell
+
+	if executor.shell == "" {
+		executor.shell = DefaultShell
+	}
+}
+
+// CommandExecutorWithOptions returns an Executor that runs an external
+// command.
+//
+// The executor returns true if the command exits with one of its success exit
+// codes, 0 unless a CommandSuccessCodesOption is specified, and its outputs
+// match the specified patterns, if any. It returns false otherwise.
+//
+// If the command cannot be run, does not complete in time or exits with one
+// of the codes of a CommandUnknownCodesOption, the executor fails.
+//
+// The result holds the exit code and the combined standard and error outputs
+// of the command.
+func CommandExecutorWithOptions(command string, args []string, options ...CommandOption) Executor {
+	executor := &commandExecutor{
+		command:      command,
+		args:         args,
+		successCodes: []int{0},
+	}
+
+	for _, option := range options {
+		option.apply(executor)
+	}
+
+	return executor.run
+}
+
+func (e *commandExecutor) cmd(ctx context.Context) *exec.Cmd {
+	var cmd *exec.Cmd
+
+	if e.shell != "" {
+		cmd = exec.CommandContext(ctx, e.shell, append([]string{"-c", e.command, e.shell}, e.args...)...)
+	} else {
+		cmd = exec.CommandContext(ctx, e.command, e.args...)
+	}
+
+	cmd.Dir = e.dir
+	cmd.WaitDelay = commandWaitDelay
+
+	if len(e.env) > 0 {
+		cmd.Env = append(os.Environ(), e.env...)
+	}
+
+	if e.stdin != nil {
+		cmd.Stdin = bytes.NewReader(e.stdin)
+	}
+
+	return cmd
+}
+
+func (e *commandExecutor) run(ctx context.Context) Result {
+	output := &snippetWriter{size: OutputSnippetSize}
+	stdout := &snippetWriter{size: MaxCommandOutputSize}
+	stderr := &snippetWriter{size: MaxCommandOutputSize}
+	cmd := e.cmd(ctx)
+	cmd.Stdout = io.MultiWriter(output, stdout)
+	cmd.Stderr = io.MultiWriter(output, stderr)
+
+	start := time.Now()
+	err := cmd.Run()
+	result := Result{
+		ExitCode: -1,
+		Latency:  time.Since(start),
+		Output:   output.String(),
+	}
+
+	if cmd.ProcessState != nil {
+		result.ExitCode = exitCode(cmd.ProcessState)
+	}
+
+	if ctx.Err() != nil {
+		result.Err = ctx.Err()
+	} else if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		result.Err = err
+	} else if containsCode(e.unknownCodes, result.ExitCode) {
+		result.Err = fmt.Errorf("command exited with code %d", result.ExitCode)
+	} else {
+		result.OK = containsCode(e.successCodes, result.ExitCode) &&
+			matchOutput(e.stdoutPattern, stdout) &&
+			matchOutput(e.stderrPattern, stderr)
+	}
+
+	return result
+}
+
+func containsCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchOutput(pattern *regexp.Regexp, output *snippetWriter) bool {
+	return pattern == nil || pattern.Match(output.Bytes())
+}
@@ -0,0 +1,84 @@
+package executor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCommandExecutorWithOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		Name     string
+		Command  string
+		Args     []string
+		Options  []CommandOption
+		Expected bool
+	}{
+		{"default", "true", nil, nil, true},
+		{"success-codes", "sh", []string{"-c", "exit 1"}, []CommandOption{CommandSuccessCodesOption{Codes: []int{0, 1}}}, true},
+		{"not-success-codes", "true", nil, []CommandOption{CommandSuccessCodesOption{Codes: []int{1}}}, false},
+		{"stdout-match", "echo", []string{"status: ok"}, []CommandOption{CommandStdoutMatchOption{Pattern: regexp.MustCompile("ok\n$")}}, true},
+		{"stdout-mismatch", "echo", []string{"status: ko"}, []CommandOption{CommandStdoutMatchOption{Pattern: regexp.MustCompile("ok\n$")}}, false},
+		{"stderr-match", "sh", []string{"-c", "echo warning >&2"}, []CommandOption{CommandStderrMatchOption{Pattern: regexp.MustCompile("warning")}}, true},
+		{"stderr-mismatch", "sh", []string{"-c", "echo warning"}, []CommandOption{CommandStderrMatchOption{Pattern: regexp.MustCompile("warning")}}, false},
+		{"dir", "sh", []string{"-c", "test \"$(pwd)\" = \"$1\"", "sh", dir}, []CommandOption{CommandDirOption{Dir: dir}}, true},
+		{"env", "sh", []string{"-c", "test \"$FOO\" = bar"}, []CommandOption{CommandEnvOption{Env: []string{"FOO=bar"}}}, true},
+		{"stdin", "grep", []string{"-q", "needle"}, []CommandOption{CommandStdinOption{Input: []byte("hay\nneedle\nhay\n")}}, true},
+		{"shell", "test \"$1\" = foo && echo done", []string{"foo"}, []CommandOption{CommandShellOption{}, CommandStdoutMatchOption{Pattern: regexp.MustCompile("^done")}}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			value := CommandExecutorWithOptions(testCase.Command, testCase.Args, testCase.Options...)(context.Background())
+
+			if !value.Known() {
+				t.Fatalf("expected a known result, got %s", value)
+			}
+
+			if value.OK != testCase.Expected {
+				t.Errorf("expected %t, got %s (%q)", testCase.Expected, value, value.Output)
+			}
+		})
+	}
+}
+
+func TestCommandExecutorUnknownCodes(t *testing.T) {
+	value := CommandExecutorWithOptions("sh", []string{"-c", "exit 3"}, CommandUnknownCodesOption{Codes: []int{3}})(context.Background())
+
+	if value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+
+	if value.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %d", value.ExitCode)
+	}
+}
+
+func TestCommandExecutorGrandchildTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The sleep process keeps the outputs of the shell open after the shell
+	// gets killed.
+	start := time.Now()
+	value := CommandExecutorWithOptions("sleep 3; true", nil, CommandShellOption{})(ctx)
+
+	if value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the executor to return shortly after its timeout, but it took %s", elapsed)
+	}
+}
@@ -0,0 +1,99 @@
+package executor
+
+// ComparisonOperator represents an operator to use in a Comparison.
+type ComparisonOperator interface {
+	Compare(value float64, reference float64) bool
+	String() string
+}
+
+var (
+	// OperatorGreater matches values strictly greater than the reference.
+	OperatorGreater ComparisonOperator = operatorGreater{}
+
+	// OperatorGreaterOrEqual matches values greater than or equal to the
+	// reference.
+	OperatorGreaterOrEqual ComparisonOperator = operatorGreaterOrEqual{}
+
+	// OperatorLess matches values strictly less than the reference.
+	OperatorLess ComparisonOperator = operatorLess{}
+
+	// OperatorLessOrEqual matches values less than or equal to the reference.
+	OperatorLessOrEqual ComparisonOperator = operatorLessOrEqual{}
+
+	// OperatorEqual matches values equal to the reference.
+	OperatorEqual ComparisonOperator = operatorEqual{}
+
+	// OperatorNotEqual matches values different from the reference.
+	OperatorNotEqual ComparisonOperator = operatorNotEqual{}
+)
+
+// A Comparison matches numeric values against a reference value.
+type Comparison struct {
+	Operator  ComparisonOperator
+	Reference float64
+}
+
+// Match tells whether the specified value matches the comparison.
+func (c Comparison) Match(value float64) bool {
+	return c.Operator.Compare(value, c.Reference)
+}
+
+type operatorGreater struct{}
+
+func (operatorGreater) Compare(value float64, reference float64) bool {
+	return value > reference
+}
+
+func (operatorGreater) String() string {
+	return ">"
+}
+
+type operatorGreaterOrEqual struct{}
+
+func (operatorGreaterOrEqual) Compare(value float64, reference float64) bool {
+	return value >= reference
+}
+
+func (operatorGreaterOrEqual) String() string {
+	return ">="
+}
+
+type operatorLess struct{}
+
+func (operatorLess) Compare(value float64, reference float64) bool {
+	return value < reference
+}
+
+func (operatorLess) String() string {
+	return "<"
+}
+
+type operatorLessOrEqual struct{}
+
+func (operatorLessOrEqual) Compare(value float64, reference float64) bool {
+	return value <= reference
+}
+
+func (operatorLessOrEqual) String() string {
+	return "<="
+}
+
+type operatorEqual struct{}
+
+func (operatorEqual) Compare(value float64, reference float64) bool {
+	return value == reference
+}
+
+func (operatorEqual) String() string {
+	return "=="
+}
+
+type operatorNotEqual struct{}
+
+func (operatorNotEqual) Compare(value float64, reference float64) bool {
+	return value != reference
+}
+
+func (operatorNotEqual) String() string {
+	return "!="
+}
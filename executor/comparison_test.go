@@ -0,0 +1,34 @@
+package executor
+
+import "testing"
+
+func TestComparison(t *testing.T) {
+	testCases := []struct {
+		Operator ComparisonOperator
+		Value    float64
+		Expected bool
+	}{
+		{OperatorGreater, 2, true},
+		{OperatorGreater, 1, false},
+		{OperatorGreaterOrEqual, 1, true},
+		{OperatorGreaterOrEqual, 0, false},
+		{OperatorLess, 0, true},
+		{OperatorLess, 1, false},
+		{OperatorLessOrEqual, 1, true},
+		{OperatorLessOrEqual, 2, false},
+		{OperatorEqual, 1, true},
+		{OperatorEqual, 2, false},
+		{OperatorNotEqual, 2, true},
+		{OperatorNotEqual, 1, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Operator.String(), func(t *testing.T) {
+			comparison := Comparison{Operator: testCase.Operator, Reference: 1}
+
+			if value := comparison.Match(testCase.Value); value != testCase.Expected {
+				t.Errorf("expected %v for %v %s 1, got %v", testCase.Expected, testCase.Value, testCase.Operator, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"time"
+)
+
+type dialExecutor struct {
+	network     string
+	address     string
+	tls         *tls.Config
+	minValidity time.Duration
+	send        []byte
+	expect      *regexp.Regexp
+}
+
+// DialOption represents an option for a dial executor.
+type DialOption interface {
+	apply(executor *dialExecutor)
+}
+
+// DialTLSOption makes a dial executor perform a TLS handshake once connected.
+type DialTLSOption struct {
+	Config *tls.Config
+}
+
+func (o DialTLSOption) apply(executor *dialExecutor) {
+	executor.tls = o.Config
+}
+
+// DialCertificateValidityOption requires the certificate of the server a dial
+// executor connects to to remain valid for at least the specified duration.
+//
+// That option requires a DialTLSOption to be specified as well.
+type DialCertificateValidityOption struct {
+	Validity time.Duration
+}
+
+func (o DialCertificateValidityOption) apply(executor *dialExecutor) {
+	executor.minValidity = o.Validity
+}
+
+// DialSendOption makes a dial executor send some data once connected.
+type DialSendOption struct {
+	Data []byte
+}
+
+func (o DialSendOption) apply(executor *dialExecutor) {
+	executor.send = o.Data
+}
+
+// DialExpectOption makes a dial executor read from the connection until the
+// received data matches the specified pattern.
+//
+// At most OutputSnippetSize bytes are read.
+type DialExpectOption struct {
+	Pattern *regexp.Regexp
+}
+
+func (o DialExpectOption) apply(executor *dialExecutor) {
+	executor.expect = o.Pattern
+}
+
+// DialExecutor returns an Executor that opens a connection to the specified
+// address.
+//
+// Supported networks are "tcp", "tcp4", "tcp6" and "unix".
+//
+// The executor returns true if the connection can be established and all its
+// options are satisfied, and false otherwise. If the context expires before
+// that, the executor fails.
+//
+// The result holds the received data, if any, or the reason of the failure.
+func DialExecutor(network string, address string, options ...DialOption) Executor {
+	executor := &dialExecutor{
+		network: network,
+		address: address,
+	}
+
+	for _, option := range options {
+		option.apply(executor)
+	}
+
+	return executor.run
+}
+
+func (e *dialExecutor) run(ctx context.Context) Result {
+	start := time.Now()
+	output, err := e.dial(ctx)
+	result := Result{
+		OK:      err == nil,
+		Latency: time.Since(start),
+		Output:  output,
+	}
+
+	if ctx.Err() != nil {
+		result.OK = false
+		result.Err = ctx.Err()
+	} else if err != nil && output == "" {
+		result.Output = err.Error()
+	}
+
+	return result
+}
+
+func (e *dialExecutor) dial(ctx context.Context) (string, error) {
+	switch e.network {
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return "", fmt.Errorf("unsupported network \"%s\"", e.network)
+	}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, e.network, e.address)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if e.tls == nil && e.minValidity > 0 {
+		return "", errors.New("a certificate validity requires TLS")
+	}
+
+	if e.tls != nil {
+		config := e.tls
+
+		if config.ServerName == "" && e.network != "unix" {
+			config = config.Clone()
+			config.ServerName, _, _ = net.SplitHostPort(e.address)
+		}
+
+		tlsConn := tls.Client(conn, config)
+
+		if err = tlsConn.Handshake(); err != nil {
+			return "", err
+		}
+
+		if e.minValidity > 0 {
+			state := tlsConn.ConnectionState()
+
+			if !certificateValidFor(&state, e.minValidity, time.Now()) {
+				return "", fmt.Errorf("certificate expires within %s", e.minValidity)
+			}
+		}
+
+		conn = tlsConn
+	}
+
+	if len(e.send) > 0 {
+		if _, err = conn.Write(e.send); err != nil {
+			return "", err
+		}
+	}
+
+	if e.expect == nil {
+		return "", nil
+	}
+
+	return e.read(conn)
+}
+
+func (e *dialExecutor) read(conn net.Conn) (string, error) {
+	buffer := make([]byte, OutputSnippetSize)
+	size := 0
+
+	for size < len(buffer) {
+		n, err := conn.Read(buffer[size:])
+		size += n
+
+		if e.expect.Match(buffer[:size]) {
+			return string(buffer[:size]), nil
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return string(buffer[:size]), err
+		}
+	}
+
+	return string(buffer[:size]), fmt.Errorf("received data does not match \"%s\"", e.expect)
+}
@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func serveBanner(listener net.Listener, banner string) {
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			buffer := make([]byte, 4)
+			conn.Read(buffer)
+			conn.Write([]byte(banner + string(buffer)))
+			conn.Close()
+		}
+	}()
+}
+
+func TestDialExecutor(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer listener.Close()
+	serveBanner(listener, "HELLO ")
+
+	address := listener.Addr().String()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	value := DialExecutor("tcp", address)(ctx)
+
+	if !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	value = DialExecutor("tcp", address, DialSendOption{Data: []byte("PING")}, DialExpectOption{Pattern: regexp.MustCompile("^HELLO PING$")})(ctx)
+
+	if !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	if value.Output != "HELLO PING" {
+		t.Errorf("expected the banner as output, got %q", value.Output)
+	}
+
+	value = DialExecutor("tcp4", address, DialSendOption{Data: []byte("PING")}, DialExpectOption{Pattern: regexp.MustCompile("PONG")})(ctx)
+
+	if value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+
+	value = DialExecutor("udp", address)(ctx)
+
+	if value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+
+	listener.Close()
+	value = DialExecutor("tcp", address)(ctx)
+
+	if value.OK || !value.Known() || value.Output == "" {
+		t.Errorf("expected false with a reason, got %s", value)
+	}
+
+	cancel()
+	value = DialExecutor("tcp", address)(ctx)
+
+	if value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+}
+
+func TestDialExecutorUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "socket")
+	listener, err := net.Listen("unix", path)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer listener.Close()
+	serveBanner(listener, "")
+
+	value := DialExecutor("unix", path)(context.Background())
+
+	if !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	value = DialExecutor("unix", filepath.Join(dir, "missing"))(context.Background())
+
+	if value.OK {
+		t.Errorf("expected false, got %s", value)
+	}
+}
+
+func TestDialExecutorTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer ts.Close()
+
+	address := ts.Listener.Addr().String()
+
+	value := DialExecutor("tcp", address, DialTLSOption{Config: &tls.Config{InsecureSkipVerify: true}})(context.Background())
+
+	if !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	value = DialExecutor("tcp", address, DialTLSOption{Config: &tls.Config{}})(context.Background())
+
+	if value.OK {
+		t.Errorf("expected false for an untrusted certificate, got %s", value)
+	}
+}
@@ -0,0 +1,205 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNSRecordTypes are the record types supported by DNS executors.
+var DNSRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "SRV", "TXT"}
+
+type dnsExecutor struct {
+	name       string
+	server     string
+	recordType string
+	expected   []string
+	resolver   *net.Resolver
+}
+
+// DNSOption represents an option for a DNS executor.
+type DNSOption interface {
+	apply(executor *dnsExecutor)
+}
+
+// DNSServerOption makes a DNS executor query the specified server, as a
+// "host:port" address, instead of the system resolvers.
+type DNSServerOption struct {
+	Address string
+}
+
+func (o DNSServerOption) apply(executor *dnsExecutor) {
+	executor.server = o.Address
+}
+
+// DNSRecordTypeOption defines the type of the records a DNS executor looks up.
+//
+// It must be one of DNSRecordTypes, and defaults to "A".
+type DNSRecordTypeOption struct {
+	Type string
+}
+
+func (o DNSRecordTypeOption) apply(executor *dnsExecutor) {
+	executor.recordType = strings.ToUpper(o.Type)
+}
+
+// DNSExpectOption requires each of the specified values to be found among
+// the records looked up by a DNS executor.
+//
+// TXT records must contain the values, while other records must be equal to
+// them. Records are formatted as addresses for A and AAAA records, as host
+// names for CNAME, MX and NS records, and as "host:port" for SRV records.
+type DNSExpectOption struct {
+	Values []string
+}
+
+func (o DNSExpectOption) apply(executor *dnsExecutor) {
+	executor.expected = append(executor.expected, o.Values...)
+}
+
+// DNSExecutor returns an Executor that looks up DNS records for the specified
+// name.
+//
+// The executor returns true if records are found and all its expected values
+// are among them, and false if none are found or some values are missing. If
+// the lookup fails for any other reason than a non-existing name, the
+// executor fails.
+//
+// The result holds the records that were found.
+func DNSExecutor(name string, options ...DNSOption) Executor {
+	executor := &dnsExecutor{
+		name:       name,
+		recordType: "A",
+		resolver:   net.DefaultResolver,
+	}
+
+	for _, option := range options {
+		option.apply(executor)
+	}
+
+	if executor.server != "" {
+		dialer := &net.Dialer{}
+		executor.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network string, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, executor.server)
+			},
+		}
+	}
+
+	return executor.run
+}
+
+func (e *dnsExecutor) run(ctx context.Context) Result {
+	start := time.Now()
+	records, err := e.lookup(ctx)
+	result := Result{
+		Latency: time.Since(start),
+		Output:  truncate(strings.Join(records, ", "), OutputSnippetSize),
+	}
+
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return result
+	}
+
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.OK = len(records) > 0
+
+	for _, value := range e.expected {
+		if !e.found(records, value) {
+			result.OK = false
+		}
+	}
+
+	return result
+}
+
+func (e *dnsExecutor) lookup(ctx context.Context) ([]string, error) {
+	var records []string
+
+	switch e.recordType {
+	case "A", "AAAA":
+		network := "ip4"
+
+		if e.recordType == "AAAA" {
+			network = "ip6"
+		}
+
+		ips, err := e.resolver.LookupIP(ctx, network, e.name)
+
+		for _, ip := range ips {
+			records = append(records, ip.String())
+		}
+
+		return records, err
+	case "CNAME":
+		cname, err := e.resolver.LookupCNAME(ctx, e.name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{trimDot(cname)}, nil
+	case "MX":
+		mxs, err := e.resolver.LookupMX(ctx, e.name)
+
+		for _, mx := range mxs {
+			records = append(records, trimDot(mx.Host))
+		}
+
+		return records, err
+	case "NS":
+		nss, err := e.resolver.LookupNS(ctx, e.name)
+
+		for _, ns := range nss {
+			records = append(records, trimDot(ns.Host))
+		}
+
+		return records, err
+	case "SRV":
+		_, srvs, err := e.resolver.LookupSRV(ctx, "", "", e.name)
+
+		for _, srv := range srvs {
+			records = append(records, net.JoinHostPort(trimDot(srv.Target), strconv.Itoa(int(srv.Port))))
+		}
+
+		return records, err
+	case "TXT":
+		return e.resolver.LookupTXT(ctx, e.name)
+	}
+
+	return nil, fmt.Errorf("unsupported DNS record type \"%s\"", e.recordType)
+}
+
+func (e *dnsExecutor) found(records []string, value string) bool {
+	for _, record := range records {
+		if e.recordType == "TXT" {
+			if strings.Contains(record, value) {
+				return true
+			}
+		} else if strings.EqualFold(record, trimDot(value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func trimDot(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+func truncate(s string, size int) string {
+	if len(s) > size {
+		return s[:size]
+	}
+
+	return s
+}
@@ -0,0 +1,175 @@
+package executor
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+const (
+	dnsTypeA    = 1
+	dnsTypeTXT  = 16
+	dnsTypeAAAA = 28
+	dnsTypeSRV  = 33
+)
+
+type dnsRecord struct {
+	Type uint16
+	Data []byte
+}
+
+func encodeDNSName(name string) []byte {
+	var data []byte
+
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		data = append(data, byte(len(label)))
+		data = append(data, label...)
+	}
+
+	return append(data, 0)
+}
+
+func encodeSRV(port uint16, target string) []byte {
+	data := make([]byte, 6)
+	binary.BigEndian.PutUint16(data[4:], port)
+
+	return append(data, encodeDNSName(target)...)
+}
+
+func encodeTXT(values ...string) []byte {
+	var data []byte
+
+	for _, value := range values {
+		data = append(data, byte(len(value)))
+		data = append(data, value...)
+	}
+
+	return data
+}
+
+// serveDNS answers the DNS queries received on the specified connection from
+// the specified records. Unknown names get a name error, except "fail." that
+// gets a server failure.
+func serveDNS(conn net.PacketConn, records map[string][]dnsRecord) {
+	buffer := make([]byte, 512)
+
+	for {
+		n, addr, err := conn.ReadFrom(buffer)
+
+		if err != nil {
+			return
+		}
+
+		query := buffer[:n]
+		offset := 12
+		var labels []string
+
+		for offset < n && query[offset] != 0 {
+			size := int(query[offset])
+			labels = append(labels, string(query[offset+1:offset+1+size]))
+			offset += size + 1
+		}
+
+		questionEnd := offset + 5
+		qtype := binary.BigEndian.Uint16(query[offset+1:])
+		name := strings.ToLower(strings.Join(labels, ".")) + "."
+
+		var answers [][]byte
+		flags := uint16(0x8580)
+		known, ok := records[name]
+
+		switch {
+		case name == "fail.":
+			flags |= 2
+		case !ok:
+			flags |= 3
+		}
+
+		for _, record := range known {
+			if record.Type != qtype {
+				continue
+			}
+
+			answer := []byte{0xc0, 0x0c, 0, 0, 0, 1, 0, 0, 0, 60, 0, 0}
+			binary.BigEndian.PutUint16(answer[2:], record.Type)
+			binary.BigEndian.PutUint16(answer[10:], uint16(len(record.Data)))
+			answers = append(answers, append(answer, record.Data...))
+		}
+
+		response := make([]byte, 12)
+		copy(response, query[:2])
+		binary.BigEndian.PutUint16(response[2:], flags)
+		binary.BigEndian.PutUint16(response[4:], 1)
+		binary.BigEndian.PutUint16(response[6:], uint16(len(answers)))
+		response = append(response, query[12:questionEnd]...)
+
+		for _, answer := range answers {
+			response = append(response, answer...)
+		}
+
+		conn.WriteTo(response, addr)
+	}
+}
+
+func TestDNSExecutor(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer conn.Close()
+
+	go serveDNS(conn, map[string][]dnsRecord{
+		"www.example.test.": {
+			{dnsTypeA, []byte{10, 0, 0, 1}},
+			{dnsTypeA, []byte{10, 0, 0, 2}},
+			{dnsTypeTXT, encodeTXT("v=spf1 -all", "owner=ops")},
+		},
+		"_db._tcp.example.test.": {
+			{dnsTypeSRV, encodeSRV(5432, "db1.example.test.")},
+		},
+	})
+
+	server := DNSServerOption{Address: conn.LocalAddr().String()}
+
+	testCases := []struct {
+		Name     string
+		Host     string
+		Options  []DNSOption
+		Expected bool
+	}{
+		{"a", "www.example.test", nil, true},
+		{"a-expected", "www.example.test", []DNSOption{DNSExpectOption{Values: []string{"10.0.0.2"}}}, true},
+		{"a-unexpected", "www.example.test", []DNSOption{DNSExpectOption{Values: []string{"10.0.0.3"}}}, false},
+		{"aaaa", "www.example.test", []DNSOption{DNSRecordTypeOption{Type: "aaaa"}}, false},
+		{"txt", "www.example.test", []DNSOption{DNSRecordTypeOption{Type: "TXT"}, DNSExpectOption{Values: []string{"owner=ops"}}}, true},
+		{"txt-unexpected", "www.example.test", []DNSOption{DNSRecordTypeOption{Type: "TXT"}, DNSExpectOption{Values: []string{"owner=dev"}}}, false},
+		{"srv", "_db._tcp.example.test", []DNSOption{DNSRecordTypeOption{Type: "SRV"}, DNSExpectOption{Values: []string{"db1.example.test:5432"}}}, true},
+		{"not-found", "missing.example.test", nil, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			value := DNSExecutor(testCase.Host, append(testCase.Options, server)...)(context.Background())
+
+			if !value.Known() {
+				t.Fatalf("expected a known result, got %s", value)
+			}
+
+			if value.OK != testCase.Expected {
+				t.Errorf("expected %t, got %s (%q)", testCase.Expected, value, value.Output)
+			}
+		})
+	}
+
+	if value := DNSExecutor("fail.", server)(context.Background()); value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+
+	if value := DNSExecutor("www.example.test", server, DNSRecordTypeOption{Type: "PTR"})(context.Background()); value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+}
@@ -2,56 +2,133 @@
 package executor
 
 import (
+	"bytes"
 	"context"
-	"io"
-	"io/ioutil"
-	"net/http"
-	"os/exec"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
 	"time"
 )
 
-// An Executor is a callback that returns a boolean status.
-type Executor func(ctx context.Context) bool
+// OutputSnippetSize is the maximum size of the output captured in a Result.
+const OutputSnippetSize = 512
+
+// A Result is the outcome of an Executor call.
+type Result struct {
+	// OK is the boolean status returned by the executor.
+	OK bool
+
+	// Err is set whenever the executor could not determine its status, in
+	// which case OK is meaningless.
+	Err error
+
+	// ExitCode is the exit code of the command run by the executor, if any.
+	ExitCode int
+
+	// StatusCode is the status code of the HTTP response received by the
+	// executor, if any.
+	StatusCode int
+
+	// Latency is the time it took for the executor to get its status.
+	Latency time.Duration
+
+	// Output is the beginning of the output of the executor, if any, up to
+	// OutputSnippetSize bytes.
+	Output string
+}
+
+// Known tells whether the result holds a status.
+func (r Result) Known() bool {
+	return r.Err == nil
+}
+
+func (r Result) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("unknown (%s)", r.Err)
+	}
+
+	return fmt.Sprintf("%t", r.OK)
+}
+
+// An Executor is a callback that returns a Result.
+type Executor func(ctx context.Context) Result
+
+// Bool returns an Executor from a callback that returns a boolean status.
+//
+// The returned executor never fails.
+func Bool(f func(ctx context.Context) bool) Executor {
+	return func(ctx context.Context) Result {
+		return Result{OK: f(ctx)}
+	}
+}
 
 // FalseExecutor returns always false.
-func FalseExecutor(ctx context.Context) bool { return false }
+func FalseExecutor(ctx context.Context) Result { return Result{OK: false} }
 
 // TrueExecutor returns always true.
-func TrueExecutor(ctx context.Context) bool { return true }
+func TrueExecutor(ctx context.Context) Result { return Result{OK: true} }
 
-// CommandExecutor returns an Executor that runs an external command.
-func CommandExecutor(command string, args ...string) Executor {
-	return func(ctx context.Context) bool {
-		return exec.CommandContext(ctx, command, args...).Run() == nil
-	}
+// snippetWriter keeps the beginning of what gets written to it, up to its
+// size, and discards the rest.
+//
+// A snippetWriter is safe for concurrent use, as it may be shared by the
+// standard output and error streams of a command.
+type snippetWriter struct {
+	size   int
+	lock   sync.Mutex
+	buffer bytes.Buffer
 }
 
-// HTTPExecutor returns an Executor that runs a HTTP request.
-func HTTPExecutor(method string, url string, statusCodes []int, timeout time.Duration) Executor {
-	return func(ctx context.Context) bool {
-		req, err := http.NewRequest(method, url, nil)
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-		if err != nil {
-			return false
+	if remaining := w.size - w.buffer.Len(); remaining > 0 {
+		if len(p) > remaining {
+			w.buffer.Write(p[:remaining])
+		} else {
+			w.buffer.Write(p)
 		}
+	}
 
-		req = req.WithContext(ctx)
-		client := &http.Client{Timeout: timeout}
+	return len(p), nil
+}
 
-		resp, err := client.Do(req)
+func (w *snippetWriter) Bytes() []byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-		if err != nil {
-			return false
-		}
+	return w.buffer.Bytes()
+}
 
-		io.Copy(ioutil.Discard, resp.Body)
+func (w *snippetWriter) String() string {
+	return string(w.Bytes())
+}
 
-		for _, statusCode := range statusCodes {
-			if statusCode == resp.StatusCode {
-				return true
-			}
-		}
+func exitCode(state *os.ProcessState) int {
+	if status, ok := state.Sys().(syscall.WaitStatus); ok {
+		return status.ExitStatus()
+	}
 
-		return false
+	if state.Success() {
+		return 0
 	}
+
+	return -1
+}
+
+// CommandExecutor returns an Executor that runs an external command.
+//
+// The executor returns true if the command exits with a zero exit code and
+// false if it exits with any other exit code. If the command cannot be run or
+// does not complete in time, the executor fails.
+//
+// The result holds the exit code and the combined standard and error outputs
+// of the command.
+//
+// CommandExecutorWithOptions allows for more control on how the command is
+// run and how its outcome is interpreted.
+func CommandExecutor(command string, args ...string) Executor {
+	return CommandExecutorWithOptions(command, args)
 }
@@ -2,16 +2,35 @@ package executor
 
 import (
 	"context"
-	"net/http"
-	"net/http/httptest"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
+func TestResult(t *testing.T) {
+	if value := (Result{OK: true}).String(); value != "true" {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	if value := (Result{Err: errors.New("fail")}).String(); value != "unknown (fail)" {
+		t.Errorf("expected unknown (fail), got %s", value)
+	}
+}
+
+func TestBool(t *testing.T) {
+	result := Bool(func(context.Context) bool { return true })(context.Background())
+
+	if !result.OK || !result.Known() {
+		t.Error("expected true")
+	}
+}
+
 func TestFalseExecutor(t *testing.T) {
 	value := FalseExecutor(context.Background())
 
-	if value {
+	if value.OK {
 		t.Error("expected false")
 	}
 }
@@ -19,7 +38,7 @@ func TestFalseExecutor(t *testing.T) {
 func TestTrueExecutor(t *testing.T) {
 	value := TrueExecutor(context.Background())
 
-	if !value {
+	if !value.OK {
 		t.Error("expected true")
 	}
 }
@@ -27,43 +46,77 @@ func TestTrueExecutor(t *testing.T) {
 func TestCommandExecutor(t *testing.T) {
 	value := CommandExecutor("echo")(context.Background())
 
-	if !value {
+	if !value.OK {
 		t.Error("expected true")
 	}
 
-	value = CommandExecutor("unknown-command")(context.Background())
+	value = CommandExecutor("false")(context.Background())
 
-	if value {
+	if value.OK || !value.Known() {
 		t.Error("expected false")
 	}
-}
 
-func TestHTTPExecutor(t *testing.T) {
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		w.WriteHeader(200)
-	}))
+	value = CommandExecutor("unknown-command")(context.Background())
+
+	if value.Known() {
+		t.Error("expected an unknown result")
+	}
 
-	value := HTTPExecutor("GET", ts.URL, []int{200}, time.Second)(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
 
-	if !value {
-		t.Error("expected true")
+	value = CommandExecutor("sleep", "1")(ctx)
+
+	if value.Known() {
+		t.Error("expected an unknown result")
 	}
+}
 
-	value = HTTPExecutor("GET", ts.URL, []int{201}, time.Second)(context.Background())
+func TestCommandExecutorResult(t *testing.T) {
+	result := CommandExecutor("sh", "-c", "echo foo; exit 3")(context.Background())
 
-	if value {
+	if result.OK || !result.Known() {
 		t.Error("expected false")
 	}
 
-	value = HTTPExecutor("GET", "http://localhost:0", []int{200}, time.Second)(context.Background())
+	if result.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %d", result.ExitCode)
+	}
 
-	if value {
-		t.Error("expected false")
+	if result.Output != "foo\n" {
+		t.Errorf("expected output \"foo\\n\", got %q", result.Output)
 	}
 
-	value = HTTPExecutor("🖕", ts.URL, []int{200}, time.Second)(context.Background())
+	if result.Latency <= 0 {
+		t.Errorf("expected a positive latency, got %s", result.Latency)
+	}
 
-	if value {
-		t.Error("expected false")
+	result = CommandExecutor("head", "-c", "1000", "/dev/zero")(context.Background())
+
+	if len(result.Output) != OutputSnippetSize {
+		t.Errorf("expected output to be truncated to %d bytes, got %d", OutputSnippetSize, len(result.Output))
+	}
+}
+
+func TestSnippetWriterConcurrent(t *testing.T) {
+	w := &snippetWriter{size: 64}
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				w.Write([]byte("ab"))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if expected := strings.Repeat("ab", 32); w.String() != expected {
+		t.Errorf("expected %q, got %q", expected, w.String())
 	}
 }
@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// FileExistsExecutor returns an Executor that is true whenever the specified
+// path exists.
+//
+// The executor fails if the existence of the path cannot be determined.
+func FileExistsExecutor(path string) Executor {
+	return func(ctx context.Context) Result {
+		_, err := os.Stat(path)
+
+		if os.IsNotExist(err) {
+			return Result{OK: false}
+		}
+
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		return Result{OK: true}
+	}
+}
+
+// FileModifiedExecutor returns an Executor that is true whenever the specified
+// path exists and was modified within the specified duration.
+//
+// The executor fails if the path cannot be inspected.
+func FileModifiedExecutor(path string, within time.Duration) Executor {
+	return func(ctx context.Context) Result {
+		info, err := os.Stat(path)
+
+		if os.IsNotExist(err) {
+			return Result{OK: false}
+		}
+
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		return Result{OK: time.Since(info.ModTime()) <= within}
+	}
+}
+
+// DirEntriesNumericExecutor returns a NumericExecutor that counts the entries
+// of the specified directory.
+func DirEntriesNumericExecutor(path string) NumericExecutor {
+	return func(ctx context.Context) (float64, error) {
+		dir, err := os.Open(path)
+
+		if err != nil {
+			return 0, err
+		}
+
+		defer dir.Close()
+
+		names, err := dir.Readdirnames(-1)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return float64(len(names)), nil
+	}
+}
+
+// FreeSpaceNumericExecutor returns a NumericExecutor that computes the
+// percentage of free space available to unprivileged users on the filesystem
+// that holds the specified path.
+func FreeSpaceNumericExecutor(path string) NumericExecutor {
+	return func(ctx context.Context) (float64, error) {
+		return freeSpace(path)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package executor
+
+import "errors"
+
+func freeSpace(path string) (float64, error) {
+	return 0, errors.New("free space is not supported on this platform")
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package executor
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func freeSpace(path string) (float64, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	if stat.Blocks == 0 {
+		return 0, fmt.Errorf("no blocks on the filesystem of \"%s\"", path)
+	}
+
+	return float64(stat.Bavail) * 100 / float64(stat.Blocks), nil
+}
@@ -0,0 +1,103 @@
+package executor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFileExecutors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lock")
+
+	if value := FileExistsExecutor(path)(context.Background()); value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+
+	if value := FileModifiedExecutor(path, time.Minute)(context.Background()); value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+
+	if err = ioutil.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if value := FileExistsExecutor(path)(context.Background()); !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	if value := FileModifiedExecutor(path, time.Minute)(context.Background()); !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	old := time.Now().Add(-time.Hour)
+
+	if err = os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if value := FileModifiedExecutor(path, time.Minute)(context.Background()); value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+}
+
+func TestDirEntriesNumericExecutor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"a", "b", "c"} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("expected no error but got: %s", err)
+		}
+	}
+
+	value, err := DirEntriesNumericExecutor(dir)(context.Background())
+
+	if err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if value != 3 {
+		t.Errorf("expected 3, got %v", value)
+	}
+
+	if _, err = DirEntriesNumericExecutor(filepath.Join(dir, "missing"))(context.Background()); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestFreeSpaceNumericExecutor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("free space is not supported on this platform")
+	}
+
+	value, err := FreeSpaceNumericExecutor(os.TempDir())(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if value < 0 || value > 100 {
+		t.Errorf("expected a percentage, got %v", value)
+	}
+
+	if _, err = FreeSpaceNumericExecutor("/missing/path")(context.Background()); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,270 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// MaxBodySize is the maximum size of the HTTP response bodies read by the
+// HTTP executor.
+const MaxBodySize = 1 << 20
+
+type httpExecutor struct {
+	method          string
+	url             string
+	statusCodes     []int
+	client          *http.Client
+	tls             *tls.Config
+	minValidity     time.Duration
+	body            []byte
+	header          http.Header
+	bodyPattern     *regexp.Regexp
+	jsonAssertions  []JSONAssertion
+	responseHeaders []httpResponseHeader
+	maxLatency      time.Duration
+}
+
+type httpResponseHeader struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// HTTPOption represents an option for a HTTP executor.
+type HTTPOption interface {
+	apply(executor *httpExecutor)
+}
+
+// HTTPTLSOption defines the TLS configuration used by a HTTP executor.
+type HTTPTLSOption struct {
+	Config *tls.Config
+}
+
+func (o HTTPTLSOption) apply(executor *httpExecutor) {
+	executor.tls = o.Config
+}
+
+// HTTPCertificateValidityOption requires the certificate of the server
+// queried by a HTTP executor to remain valid for at least the specified
+// duration.
+//
+// Requests that are not performed over TLS never satisfy that option.
+type HTTPCertificateValidityOption struct {
+	Validity time.Duration
+}
+
+func (o HTTPCertificateValidityOption) apply(executor *httpExecutor) {
+	executor.minValidity = o.Validity
+}
+
+// HTTPBodyOption defines the body of the requests sent by a HTTP executor.
+type HTTPBodyOption struct {
+	Body []byte
+}
+
+func (o HTTPBodyOption) apply(executor *httpExecutor) {
+	executor.body = o.Body
+}
+
+// HTTPHeaderOption adds a header to the requests sent by a HTTP executor.
+type HTTPHeaderOption struct {
+	Name  string
+	Value string
+}
+
+func (o HTTPHeaderOption) apply(executor *httpExecutor) {
+	executor.header.Add(o.Name, o.Value)
+}
+
+// HTTPBodyMatchOption requires the body of the responses received by a HTTP
+// executor to match the specified pattern.
+type HTTPBodyMatchOption struct {
+	Pattern *regexp.Regexp
+}
+
+func (o HTTPBodyMatchOption) apply(executor *httpExecutor) {
+	executor.bodyPattern = o.Pattern
+}
+
+// HTTPJSONOption requires the body of the responses received by a HTTP
+// executor to be a JSON document that matches the specified assertion.
+type HTTPJSONOption struct {
+	Assertion JSONAssertion
+}
+
+func (o HTTPJSONOption) apply(executor *httpExecutor) {
+	executor.jsonAssertions = append(executor.jsonAssertions, o.Assertion)
+}
+
+// HTTPResponseHeaderOption requires the responses received by a HTTP executor
+// to have the specified header.
+//
+// If a pattern is specified, the header value must also match it.
+type HTTPResponseHeaderOption struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+func (o HTTPResponseHeaderOption) apply(executor *httpExecutor) {
+	executor.responseHeaders = append(executor.responseHeaders, httpResponseHeader{
+		name:    o.Name,
+		pattern: o.Pattern,
+	})
+}
+
+// HTTPMaxLatencyOption requires the responses received by a HTTP executor to
+// arrive within the specified duration.
+type HTTPMaxLatencyOption struct {
+	Latency time.Duration
+}
+
+func (o HTTPMaxLatencyOption) apply(executor *httpExecutor) {
+	executor.maxLatency = o.Latency
+}
+
+// HTTPExecutor returns an Executor that runs a HTTP request.
+//
+// The executor returns true if the response has one of the specified status
+// codes and satisfies all the assertions specified as options, and false
+// otherwise. If the request cannot be performed, the executor fails.
+//
+// The result holds the status code and the beginning of the body of the
+// response.
+func HTTPExecutor(method string, url string, statusCodes []int, timeout time.Duration, options ...HTTPOption) Executor {
+	executor := &httpExecutor{
+		method:      method,
+		url:         url,
+		statusCodes: statusCodes,
+		header:      make(http.Header),
+	}
+
+	for _, option := range options {
+		option.apply(executor)
+	}
+
+	executor.client = &http.Client{Timeout: timeout}
+
+	if executor.tls != nil {
+		executor.client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: executor.tls,
+		}
+	}
+
+	return executor.run
+}
+
+func (e *httpExecutor) run(ctx context.Context) Result {
+	req, err := http.NewRequest(e.method, e.url, bytes.NewReader(e.body))
+
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	for name, values := range e.header {
+		req.Header[name] = values
+	}
+
+	if host := e.header.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+	resp, err := e.client.Do(req)
+
+	if err != nil {
+		return Result{Err: err, Latency: time.Since(start)}
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	result := Result{
+		StatusCode: resp.StatusCode,
+		Latency:    time.Since(start),
+		Output:     string(body),
+	}
+
+	if len(result.Output) > OutputSnippetSize {
+		result.Output = result.Output[:OutputSnippetSize]
+	}
+
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.OK = e.check(resp, body, result.Latency)
+
+	return result
+}
+
+func (e *httpExecutor) check(resp *http.Response, body []byte, latency time.Duration) bool {
+	if !e.checkStatusCode(resp.StatusCode) {
+		return false
+	}
+
+	if e.maxLatency > 0 && latency > e.maxLatency {
+		return false
+	}
+
+	if e.minValidity > 0 && !certificateValidFor(resp.TLS, e.minValidity, time.Now()) {
+		return false
+	}
+
+	for _, header := range e.responseHeaders {
+		values, ok := resp.Header[http.CanonicalHeaderKey(header.name)]
+
+		if !ok || (header.pattern != nil && !matchAny(header.pattern, values)) {
+			return false
+		}
+	}
+
+	if e.bodyPattern != nil && !e.bodyPattern.Match(body) {
+		return false
+	}
+
+	if len(e.jsonAssertions) > 0 {
+		var document interface{}
+
+		if err := json.Unmarshal(body, &document); err != nil {
+			return false
+		}
+
+		for _, assertion := range e.jsonAssertions {
+			if ok, _ := assertion.Match(document); !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (e *httpExecutor) checkStatusCode(statusCode int) bool {
+	for _, expected := range e.statusCodes {
+		if expected == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(pattern *regexp.Regexp, values []string) bool {
+	for _, value := range values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package executor
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestHTTPExecutor(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer ts.Close()
+
+	value := HTTPExecutor("GET", ts.URL, []int{200}, time.Second)(context.Background())
+
+	if !value.OK {
+		t.Error("expected true")
+	}
+
+	value = HTTPExecutor("GET", ts.URL, []int{201}, time.Second)(context.Background())
+
+	if value.OK || !value.Known() {
+		t.Error("expected false")
+	}
+
+	value = HTTPExecutor("GET", "http://localhost:0", []int{200}, time.Second)(context.Background())
+
+	if value.OK || value.Known() {
+		t.Error("expected an unknown result")
+	}
+
+	value = HTTPExecutor("🖕", ts.URL, []int{200}, time.Second)(context.Background())
+
+	if value.OK || value.Known() {
+		t.Error("expected an unknown result")
+	}
+}
+
+func TestHTTPExecutorResult(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(503)
+		w.Write([]byte("unavailable"))
+	}))
+	defer ts.Close()
+
+	result := HTTPExecutor("GET", ts.URL, []int{200}, time.Second)(context.Background())
+
+	if result.OK || !result.Known() {
+		t.Error("expected false")
+	}
+
+	if result.StatusCode != 503 {
+		t.Errorf("expected status code 503, got %d", result.StatusCode)
+	}
+
+	if result.Output != "unavailable" {
+		t.Errorf("expected output \"unavailable\", got %q", result.Output)
+	}
+}
+
+func TestHTTPExecutorOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"echo": "` + string(body) + `", "count": 3}`))
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		Name     string
+		Options  []HTTPOption
+		Expected bool
+	}{
+		{"no-authorization", nil, false},
+		{"authorization", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}}, true},
+		{"body-match", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPBodyOption{[]byte("ping")}, HTTPBodyMatchOption{regexp.MustCompile(`"echo": "ping"`)}}, true},
+		{"body-mismatch", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPBodyMatchOption{regexp.MustCompile("pong")}}, false},
+		{"json", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPJSONOption{JSONAssertion{"$.count", OperatorGreaterOrEqual, 3}}}, true},
+		{"json-mismatch", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPJSONOption{JSONAssertion{"$.count", OperatorLess, 3}}}, false},
+		{"json-missing", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPJSONOption{JSONAssertion{"$.missing", OperatorEqual, 3}}}, false},
+		{"header", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPResponseHeaderOption{"content-type", regexp.MustCompile("^application/json$")}}, true},
+		{"header-presence", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPResponseHeaderOption{"Content-Type", nil}}, true},
+		{"header-missing", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPResponseHeaderOption{"X-Missing", nil}}, false},
+		{"header-mismatch", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPResponseHeaderOption{"Content-Type", regexp.MustCompile("xml")}}, false},
+		{"max-latency", []HTTPOption{HTTPHeaderOption{"Authorization", "Bearer token"}, HTTPMaxLatencyOption{time.Nanosecond}}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			result := HTTPExecutor("POST", ts.URL, []int{200}, time.Second, testCase.Options...)(context.Background())
+
+			if !result.Known() {
+				t.Fatalf("expected a known result, got %s", result)
+			}
+
+			if result.OK != testCase.Expected {
+				t.Errorf("expected %v, got %s", testCase.Expected, result)
+			}
+		})
+	}
+}
+
+func TestHTTPExecutorInvalidJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer ts.Close()
+
+	result := HTTPExecutor("GET", ts.URL, []int{200}, time.Second, HTTPJSONOption{JSONAssertion{"$.a", OperatorEqual, 1}})(context.Background())
+
+	if result.OK || !result.Known() {
+		t.Errorf("expected false, got %s", result)
+	}
+}
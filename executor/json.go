@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A JSONAssertion compares a value of a JSON document with a reference value.
+type JSONAssertion struct {
+	// Path locates the value in the document, as a JSONPath-style expression
+	// made of dot-separated keys and bracketed indexes, like "$.a.b[0].c".
+	Path string
+
+	// Operator is the comparison operator. Only OperatorEqual and
+	// OperatorNotEqual can be used with non-numeric values.
+	Operator ComparisonOperator
+
+	// Value is the reference value.
+	Value interface{}
+}
+
+// Match tells whether the specified decoded JSON document matches the
+// assertion.
+//
+// If the path does not exist in the document or the values cannot be
+// compared, an error is returned.
+func (a JSONAssertion) Match(document interface{}) (bool, error) {
+	value, err := lookupJSONPath(document, a.Path)
+
+	if err != nil {
+		return false, err
+	}
+
+	actual, actualOk := toFloat(value)
+	reference, referenceOk := toFloat(a.Value)
+
+	if actualOk && referenceOk {
+		return a.Operator.Compare(actual, reference), nil
+	}
+
+	switch a.Operator {
+	case OperatorEqual:
+		return reflect.DeepEqual(value, a.Value), nil
+	case OperatorNotEqual:
+		return !reflect.DeepEqual(value, a.Value), nil
+	}
+
+	return false, fmt.Errorf("cannot compare %v %s %v", value, a.Operator, a.Value)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case uint:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	}
+
+	return 0, false
+}
+
+func splitJSONPath(path string) ([]string, error) {
+	path = strings.TrimPrefix(path, "$")
+	var tokens []string
+
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+
+			if end < 0 {
+				end = len(path)
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in JSON path")
+			}
+
+			tokens = append(tokens, path[:end])
+			path = path[end:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in JSON path")
+			}
+
+			tokens = append(tokens, strings.Trim(path[1:end], "'\""))
+			path = path[end+1:]
+		default:
+			path = "." + path
+		}
+	}
+
+	return tokens, nil
+}
+
+func lookupJSONPath(document interface{}, path string) (interface{}, error) {
+	tokens, err := splitJSONPath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	value := document
+
+	for _, token := range tokens {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+
+			if value, ok = current[token]; !ok {
+				return nil, fmt.Errorf("no key \"%s\" in JSON path \"%s\"", token, path)
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, fmt.Errorf("invalid index \"%s\" in JSON path \"%s\"", token, path)
+			}
+
+			value = current[index]
+		default:
+			return nil, fmt.Errorf("cannot lookup \"%s\" in JSON path \"%s\"", token, path)
+		}
+	}
+
+	return value, nil
+}
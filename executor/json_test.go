@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONAssertion(t *testing.T) {
+	var document interface{}
+	json.Unmarshal([]byte(`{"status": "up", "checks": [{"latency": 12.5}, {"ok": true}], "name": null}`), &document)
+
+	testCases := []struct {
+		Assertion     JSONAssertion
+		Expected      bool
+		ExpectFailure bool
+	}{
+		{JSONAssertion{"$.status", OperatorEqual, "up"}, true, false},
+		{JSONAssertion{"status", OperatorNotEqual, "up"}, false, false},
+		{JSONAssertion{"$.checks[0].latency", OperatorLess, 20}, true, false},
+		{JSONAssertion{"$.checks[0].latency", OperatorGreater, 20.0}, false, false},
+		{JSONAssertion{"$.checks.1.ok", OperatorEqual, true}, true, false},
+		{JSONAssertion{"$['status']", OperatorEqual, "up"}, true, false},
+		{JSONAssertion{"$.name", OperatorEqual, nil}, true, false},
+		{JSONAssertion{"$.status", OperatorGreater, 1}, false, true},
+		{JSONAssertion{"$.missing", OperatorEqual, "up"}, false, true},
+		{JSONAssertion{"$.checks[2]", OperatorEqual, "up"}, false, true},
+		{JSONAssertion{"$.status.foo", OperatorEqual, "up"}, false, true},
+		{JSONAssertion{"$.checks[0", OperatorEqual, "up"}, false, true},
+		{JSONAssertion{"$..status", OperatorEqual, "up"}, false, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Assertion.Path, func(t *testing.T) {
+			value, err := testCase.Assertion.Match(document)
+
+			if testCase.ExpectFailure {
+				if err == nil {
+					t.Error("expected an error")
+				}
+			} else if err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if value != testCase.Expected {
+				t.Errorf("expected %v, got %v", testCase.Expected, value)
+			}
+		})
+	}
+}
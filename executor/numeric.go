@@ -0,0 +1,41 @@
+package executor
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// A NumericExecutor is a callback that returns a numeric value.
+type NumericExecutor func(ctx context.Context) (float64, error)
+
+// CommandNumericExecutor returns a NumericExecutor that runs an external
+// command and parses its standard output as a number.
+func CommandNumericExecutor(command string, args ...string) NumericExecutor {
+	return func(ctx context.Context) (float64, error) {
+		output, err := exec.CommandContext(ctx, command, args...).Output()
+
+		if err != nil {
+			return 0, err
+		}
+
+		return strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	}
+}
+
+// ThresholdExecutor returns an Executor that is true whenever the value
+// returned by the specified NumericExecutor matches the comparison.
+//
+// If the NumericExecutor fails, the Executor fails too.
+func ThresholdExecutor(executor NumericExecutor, comparison Comparison) Executor {
+	return func(ctx context.Context) Result {
+		value, err := executor(ctx)
+
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		return Result{OK: comparison.Match(value)}
+	}
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCommandNumericExecutor(t *testing.T) {
+	value, err := CommandNumericExecutor("echo", "42.5")(context.Background())
+
+	if err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if value != 42.5 {
+		t.Errorf("expected 42.5, got %v", value)
+	}
+
+	_, err = CommandNumericExecutor("echo", "foo")(context.Background())
+
+	if err == nil {
+		t.Error("expected an error")
+	}
+
+	_, err = CommandNumericExecutor("unknown-command")(context.Background())
+
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestThresholdExecutor(t *testing.T) {
+	comparison := Comparison{Operator: OperatorGreater, Reference: 10}
+	numeric := func(value float64, err error) NumericExecutor {
+		return func(context.Context) (float64, error) { return value, err }
+	}
+
+	if result := ThresholdExecutor(numeric(11, nil), comparison)(context.Background()); !result.OK {
+		t.Error("expected true")
+	}
+
+	if result := ThresholdExecutor(numeric(9, nil), comparison)(context.Background()); result.OK || !result.Known() {
+		t.Error("expected false")
+	}
+
+	if result := ThresholdExecutor(numeric(11, errors.New("fail")), comparison)(context.Background()); result.Known() {
+		t.Error("expected an unknown result")
+	}
+}
@@ -0,0 +1,172 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProcRoot is the default mount point of the proc filesystem.
+const DefaultProcRoot = "/proc"
+
+type processExecutor struct {
+	root string
+}
+
+// ProcessOption represents an option for a process executor.
+type ProcessOption interface {
+	apply(executor *processExecutor)
+}
+
+// ProcessRootOption defines the mount point of the proc filesystem inspected
+// by a process executor.
+type ProcessRootOption struct {
+	Root string
+}
+
+func (o ProcessRootOption) apply(executor *processExecutor) {
+	executor.root = o.Root
+}
+
+func newProcessExecutor(options []ProcessOption) *processExecutor {
+	executor := &processExecutor{
+		root: DefaultProcRoot,
+	}
+
+	for _, option := range options {
+		option.apply(executor)
+	}
+
+	return executor
+}
+
+// ProcessExecutor returns an Executor that is true whenever a process with
+// the specified name is running.
+//
+// A process matches if either its command name or the base name of its
+// executable, as found in its command line, is the specified name. Zombie
+// processes never match.
+//
+// The executor fails if the proc filesystem cannot be read.
+func ProcessExecutor(name string, options ...ProcessOption) Executor {
+	executor := newProcessExecutor(options)
+
+	return func(ctx context.Context) Result {
+		pids, err := executor.pids()
+
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		for _, pid := range pids {
+			if ctx.Err() != nil {
+				return Result{Err: ctx.Err()}
+			}
+
+			if executor.running(pid) && executor.named(pid, name) {
+				return Result{OK: true, Output: strconv.Itoa(pid)}
+			}
+		}
+
+		return Result{OK: false}
+	}
+}
+
+// PidFileExecutor returns an Executor that is true whenever the process whose
+// identifier is stored in the specified file is running.
+//
+// A missing pidfile is considered as false. The executor fails if the
+// pidfile cannot be read or parsed.
+func PidFileExecutor(path string, options ...ProcessOption) Executor {
+	executor := newProcessExecutor(options)
+
+	return func(ctx context.Context) Result {
+		data, err := ioutil.ReadFile(path)
+
+		if os.IsNotExist(err) {
+			return Result{OK: false}
+		}
+
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+
+		if err != nil {
+			return Result{Err: fmt.Errorf("invalid pidfile \"%s\": %s", path, err)}
+		}
+
+		return Result{OK: executor.running(pid), Output: strconv.Itoa(pid)}
+	}
+}
+
+func (e *processExecutor) pids() ([]int, error) {
+	dir, err := os.Open(e.root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+
+	for _, name := range names {
+		if pid, err := strconv.Atoi(name); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+// running tells whether the specified process exists and is not a zombie.
+func (e *processExecutor) running(pid int) bool {
+	data, err := ioutil.ReadFile(filepath.Join(e.root, strconv.Itoa(pid), "stat"))
+
+	if err != nil {
+		return false
+	}
+
+	// The command name is parenthesized and may contain anything, including
+	// parentheses, so the state is looked up after the last one.
+	end := bytes.LastIndexByte(data, ')')
+
+	if end < 0 || end+2 >= len(data) {
+		return false
+	}
+
+	return data[end+2] != 'Z'
+}
+
+func (e *processExecutor) named(pid int, name string) bool {
+	dir := filepath.Join(e.root, strconv.Itoa(pid))
+
+	if comm, err := ioutil.ReadFile(filepath.Join(dir, "comm")); err == nil {
+		if strings.TrimSpace(string(comm)) == name {
+			return true
+		}
+	}
+
+	if cmdline, err := ioutil.ReadFile(filepath.Join(dir, "cmdline")); err == nil {
+		args := bytes.SplitN(cmdline, []byte{0}, 2)
+
+		if len(args[0]) > 0 && filepath.Base(string(args[0])) == name {
+			return true
+		}
+	}
+
+	return false
+}
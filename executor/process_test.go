@@ -0,0 +1,138 @@
+package executor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func makeProcRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	processes := []struct {
+		Pid     string
+		Comm    string
+		Cmdline string
+		Stat    string
+	}{
+		{"1", "init", "/sbin/init\x00", "1 (init) S 0"},
+		{"123", "nginx", "/usr/sbin/nginx\x00-g\x00daemon off;\x00", "123 (nginx) S 1"},
+		{"456", "worker (1)", "/opt/app/long-worker-name\x00", "456 (worker (1)) R 1"},
+		{"789", "defunct", "", "789 (defunct) Z 1"},
+	}
+
+	for _, process := range processes {
+		dir := filepath.Join(root, process.Pid)
+
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("expected no error but got: %s", err)
+		}
+
+		ioutil.WriteFile(filepath.Join(dir, "comm"), []byte(process.Comm+"\n"), 0644)
+		ioutil.WriteFile(filepath.Join(dir, "cmdline"), []byte(process.Cmdline), 0644)
+		ioutil.WriteFile(filepath.Join(dir, "stat"), []byte(process.Stat), 0644)
+	}
+
+	ioutil.WriteFile(filepath.Join(root, "uptime"), []byte("1.0 1.0"), 0644)
+
+	return root
+}
+
+func TestProcessExecutor(t *testing.T) {
+	root := makeProcRoot(t)
+	defer os.RemoveAll(root)
+
+	testCases := []struct {
+		Name     string
+		Expected bool
+	}{
+		{"nginx", true},
+		{"worker (1)", true},
+		{"long-worker-name", true},
+		{"defunct", false},
+		{"apache", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			value := ProcessExecutor(testCase.Name, ProcessRootOption{Root: root})(context.Background())
+
+			if !value.Known() {
+				t.Fatalf("expected a known result, got %s", value)
+			}
+
+			if value.OK != testCase.Expected {
+				t.Errorf("expected %t, got %s", testCase.Expected, value)
+			}
+		})
+	}
+
+	value := ProcessExecutor("nginx", ProcessRootOption{Root: filepath.Join(root, "missing")})(context.Background())
+
+	if value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+}
+
+func TestPidFileExecutor(t *testing.T) {
+	root := makeProcRoot(t)
+	defer os.RemoveAll(root)
+
+	path := filepath.Join(root, "app.pid")
+
+	if value := PidFileExecutor(path, ProcessRootOption{Root: root})(context.Background()); value.OK || !value.Known() {
+		t.Errorf("expected false for a missing pidfile, got %s", value)
+	}
+
+	testCases := []struct {
+		Content  string
+		Expected bool
+	}{
+		{"123\n", true},
+		{"789", false},
+		{"999", false},
+	}
+
+	for _, testCase := range testCases {
+		ioutil.WriteFile(path, []byte(testCase.Content), 0644)
+		value := PidFileExecutor(path, ProcessRootOption{Root: root})(context.Background())
+
+		if !value.Known() || value.OK != testCase.Expected {
+			t.Errorf("expected %t for %q, got %s", testCase.Expected, testCase.Content, value)
+		}
+	}
+
+	ioutil.WriteFile(path, []byte("foo"), 0644)
+
+	if value := PidFileExecutor(path, ProcessRootOption{Root: root})(context.Background()); value.Known() {
+		t.Errorf("expected an unknown result for an invalid pidfile, got %s", value)
+	}
+}
+
+func TestPidFileExecutorProc(t *testing.T) {
+	if _, err := os.Stat(DefaultProcRoot); err != nil {
+		t.Skip("no proc filesystem available")
+	}
+
+	file, err := ioutil.TempFile("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.Remove(file.Name())
+
+	file.WriteString(strconv.Itoa(os.Getpid()))
+	file.Close()
+
+	if value := PidFileExecutor(file.Name())(context.Background()); !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+}
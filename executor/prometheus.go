@@ -0,0 +1,268 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type prometheusQuery struct {
+	endpoint string
+	query    string
+	header   http.Header
+	client   *http.Client
+	tls      *tls.Config
+	matchAll bool
+}
+
+// PrometheusOption represents an option for a Prometheus executor.
+type PrometheusOption interface {
+	apply(query *prometheusQuery)
+}
+
+// PrometheusHeaderOption adds a header to the requests sent by a Prometheus
+// executor.
+type PrometheusHeaderOption struct {
+	Name  string
+	Value string
+}
+
+func (o PrometheusHeaderOption) apply(query *prometheusQuery) {
+	query.header.Add(o.Name, o.Value)
+}
+
+// PrometheusTLSOption defines the TLS configuration used by a Prometheus
+// executor.
+type PrometheusTLSOption struct {
+	Config *tls.Config
+}
+
+func (o PrometheusTLSOption) apply(query *prometheusQuery) {
+	query.tls = o.Config
+}
+
+// PrometheusMatchAllOption makes a Prometheus executor true only if all the
+// samples of the query result match its comparison, instead of any of them.
+type PrometheusMatchAllOption struct{}
+
+func (o PrometheusMatchAllOption) apply(query *prometheusQuery) {
+	query.matchAll = true
+}
+
+type prometheusSample struct {
+	labels map[string]string
+	value  float64
+}
+
+func (s prometheusSample) String() string {
+	var labels []string
+
+	for name, value := range s.labels {
+		labels = append(labels, fmt.Sprintf("%s=%q", name, value))
+	}
+
+	if len(labels) == 0 {
+		return strconv.FormatFloat(s.value, 'g', -1, 64)
+	}
+
+	sort.Strings(labels)
+
+	return fmt.Sprintf("{%s} %s", strings.Join(labels, ","), strconv.FormatFloat(s.value, 'g', -1, 64))
+}
+
+func newPrometheusQuery(endpoint string, query string, options []PrometheusOption) *prometheusQuery {
+	q := &prometheusQuery{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		query:    query,
+		header:   make(http.Header),
+	}
+
+	for _, option := range options {
+		option.apply(q)
+	}
+
+	q.client = &http.Client{}
+
+	if q.tls != nil {
+		q.client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: q.tls,
+		}
+	}
+
+	return q
+}
+
+// PrometheusExecutor returns an Executor that runs an instant PromQL query
+// against the HTTP API of the Prometheus server at the specified endpoint,
+// like "http://localhost:9090".
+//
+// The executor returns true if any sample of the resulting vector or scalar
+// matches the comparison, or all of them if a PrometheusMatchAllOption is
+// specified, and false otherwise. An empty vector is false. If the query
+// fails, the executor fails.
+//
+// The result holds the samples of the query result.
+func PrometheusExecutor(endpoint string, query string, comparison Comparison, options ...PrometheusOption) Executor {
+	q := newPrometheusQuery(endpoint, query, options)
+
+	return func(ctx context.Context) Result {
+		start := time.Now()
+		samples, err := q.run(ctx)
+		result := Result{
+			Latency: time.Since(start),
+		}
+
+		if err != nil {
+			result.Err = err
+			return result
+		}
+
+		var outputs []string
+		matches := 0
+
+		for _, sample := range samples {
+			outputs = append(outputs, sample.String())
+
+			if comparison.Match(sample.value) {
+				matches++
+			}
+		}
+
+		result.Output = truncate(strings.Join(outputs, "\n"), OutputSnippetSize)
+
+		if q.matchAll {
+			result.OK = len(samples) > 0 && matches == len(samples)
+		} else {
+			result.OK = matches > 0
+		}
+
+		return result
+	}
+}
+
+// PrometheusNumericExecutor returns a NumericExecutor that runs an instant
+// PromQL query against the HTTP API of the Prometheus server at the specified
+// endpoint, and returns its value.
+//
+// The query must result in a scalar or in a vector with exactly one sample.
+func PrometheusNumericExecutor(endpoint string, query string, options ...PrometheusOption) NumericExecutor {
+	q := newPrometheusQuery(endpoint, query, options)
+
+	return func(ctx context.Context) (float64, error) {
+		samples, err := q.run(ctx)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if len(samples) != 1 {
+			return 0, fmt.Errorf("expected one sample but got %d", len(samples))
+		}
+
+		return samples[0].value, nil
+	}
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (q *prometheusQuery) run(ctx context.Context) ([]prometheusSample, error) {
+	req, err := http.NewRequest("GET", q.endpoint+"/api/v1/query?"+url.Values{"query": {q.query}}.Encode(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range q.header {
+		req.Header[name] = values
+	}
+
+	resp, err := q.client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var response prometheusResponse
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, MaxBodySize)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid Prometheus response (%s): %s", resp.Status, err)
+	}
+
+	if response.Status != "success" {
+		return nil, fmt.Errorf("Prometheus query failed: %s", response.Error)
+	}
+
+	switch response.Data.ResultType {
+	case "scalar":
+		var value []interface{}
+
+		if err = json.Unmarshal(response.Data.Result, &value); err != nil {
+			return nil, err
+		}
+
+		sample, err := parsePrometheusValue(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []prometheusSample{{value: sample}}, nil
+	case "vector":
+		var vector []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		}
+
+		if err = json.Unmarshal(response.Data.Result, &vector); err != nil {
+			return nil, err
+		}
+
+		samples := make([]prometheusSample, len(vector))
+
+		for i, item := range vector {
+			if samples[i].value, err = parsePrometheusValue(item.Value); err != nil {
+				return nil, err
+			}
+
+			samples[i].labels = item.Metric
+		}
+
+		return samples, nil
+	}
+
+	return nil, fmt.Errorf("unsupported Prometheus result type \"%s\"", response.Data.ResultType)
+}
+
+// parsePrometheusValue parses a [timestamp, "value"] pair.
+func parsePrometheusValue(value []interface{}) (float64, error) {
+	if len(value) != 2 {
+		return 0, errors.New("invalid Prometheus sample")
+	}
+
+	s, ok := value[1].(string)
+
+	if !ok {
+		return 0, errors.New("invalid Prometheus sample value")
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPrometheusServer(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"error_rate":  `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[1500000000,"0.02"]},{"metric":{"job":"web"},"value":[1500000000,"0.001"]}]}}`,
+		"up":          `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[1500000000,"1"]}]}}`,
+		"scalar(1.5)": `{"status":"success","data":{"resultType":"scalar","result":[1500000000,"1.5"]}}`,
+		"absent":      `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"matrix":      `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		"invalid(":    `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		response, ok := responses[req.URL.Query().Get("query")]
+
+		if !ok {
+			t.Errorf("unexpected query: %s", req.URL.Query().Get("query"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, response)
+	}))
+}
+
+func TestPrometheusExecutor(t *testing.T) {
+	ts := newPrometheusServer(t)
+	defer ts.Close()
+
+	auth := PrometheusHeaderOption{Name: "Authorization", Value: "Bearer token"}
+	greater := func(reference float64) Comparison {
+		return Comparison{Operator: OperatorGreater, Reference: reference}
+	}
+
+	testCases := []struct {
+		Name       string
+		Query      string
+		Comparison Comparison
+		Options    []PrometheusOption
+		Expected   bool
+	}{
+		{"any", "error_rate", greater(0.01), nil, true},
+		{"all", "error_rate", greater(0.01), []PrometheusOption{PrometheusMatchAllOption{}}, false},
+		{"none", "error_rate", greater(0.1), nil, false},
+		{"scalar", "scalar(1.5)", greater(1), nil, true},
+		{"empty", "absent", greater(0), nil, false},
+		{"empty-all", "absent", greater(0), []PrometheusOption{PrometheusMatchAllOption{}}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			value := PrometheusExecutor(ts.URL+"/", testCase.Query, testCase.Comparison, append(testCase.Options, auth)...)(context.Background())
+
+			if !value.Known() {
+				t.Fatalf("expected a known result, got %s", value)
+			}
+
+			if value.OK != testCase.Expected {
+				t.Errorf("expected %t, got %s (%q)", testCase.Expected, value, value.Output)
+			}
+		})
+	}
+
+	value := PrometheusExecutor(ts.URL, "error_rate", greater(0), auth)(context.Background())
+
+	if expected := "{job=\"api\"} 0.02\n{job=\"web\"} 0.001"; value.Output != expected {
+		t.Errorf("expected %q as output, got %q", expected, value.Output)
+	}
+
+	for _, query := range []string{"matrix", "invalid("} {
+		if value := PrometheusExecutor(ts.URL, query, greater(0), auth)(context.Background()); value.Known() {
+			t.Errorf("expected an unknown result for %s, got %s", query, value)
+		}
+	}
+
+	if value := PrometheusExecutor(ts.URL, "up", greater(0))(context.Background()); value.Known() {
+		t.Errorf("expected an unknown result without authorization, got %s", value)
+	}
+}
+
+func TestPrometheusNumericExecutor(t *testing.T) {
+	ts := newPrometheusServer(t)
+	defer ts.Close()
+
+	auth := PrometheusHeaderOption{Name: "Authorization", Value: "Bearer token"}
+
+	for query, expected := range map[string]float64{"up": 1, "scalar(1.5)": 1.5} {
+		value, err := PrometheusNumericExecutor(ts.URL, query, auth)(context.Background())
+
+		if err != nil {
+			t.Errorf("expected no error but got: %s", err)
+		}
+
+		if value != expected {
+			t.Errorf("expected %v for %s, got %v", expected, query, value)
+		}
+	}
+
+	for _, query := range []string{"error_rate", "absent"} {
+		if _, err := PrometheusNumericExecutor(ts.URL, query, auth)(context.Background()); err == nil {
+			t.Errorf("expected an error for %s", query)
+		}
+	}
+}
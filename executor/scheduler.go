@@ -0,0 +1,255 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultSchedulerJitter is the default jitter of a Scheduler.
+const DefaultSchedulerJitter = 0.1
+
+// DefaultScheduler is the Scheduler used when none is specified.
+//
+// It has the default jitter and no concurrency limit.
+var DefaultScheduler = NewScheduler()
+
+// A Scheduler calls executors periodically.
+//
+// Each executor call, or probe, runs in its own goroutine, so that slow
+// executors do not delay other executors. A Scheduler can limit the number of
+// concurrent probes, and randomizes the delay between probes to avoid firing
+// them all at once.
+type Scheduler struct {
+	jitter   float64
+	slots    chan struct{}
+	lock     sync.Mutex
+	stats    SchedulerStats
+	randLock sync.Mutex
+	rand     *rand.Rand
+}
+
+// SchedulerStats reports the activity of a Scheduler.
+type SchedulerStats struct {
+	// Schedules is the number of executors currently scheduled.
+	Schedules int
+
+	// Running is the number of probes currently running.
+	Running int
+
+	// Waiting is the number of probes waiting for the concurrency limit to
+	// allow them to run.
+	Waiting int
+
+	// Probes is the total number of probes that ran.
+	Probes uint64
+
+	// Overruns is the total number of probes that were skipped because the
+	// previous probe of the same executor was still running or waiting.
+	Overruns uint64
+}
+
+// SchedulerOption represents an option for a Scheduler.
+type SchedulerOption interface {
+	apply(scheduler *Scheduler)
+}
+
+// SchedulerJitterOption defines the jitter of a Scheduler, as a fraction of
+// the period of its executors.
+//
+// The delay between two probes of an executor is randomly chosen within
+// [period * (1 - jitter), period * (1 + jitter)].
+type SchedulerJitterOption struct {
+	Jitter float64
+}
+
+func (o SchedulerJitterOption) apply(scheduler *Scheduler) {
+	scheduler.jitter = o.Jitter
+}
+
+// SchedulerConcurrencyOption limits the number of concurrent probes of a
+// Scheduler.
+//
+// A limit of zero means no limit.
+type SchedulerConcurrencyOption struct {
+	Limit int
+}
+
+func (o SchedulerConcurrencyOption) apply(scheduler *Scheduler) {
+	if o.Limit > 0 {
+		scheduler.slots = make(chan struct{}, o.Limit)
+	} else {
+		scheduler.slots = nil
+	}
+}
+
+// NewScheduler instantiates a new Scheduler.
+func NewScheduler(options ...SchedulerOption) *Scheduler {
+	scheduler := &Scheduler{
+		jitter: DefaultSchedulerJitter,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	for _, option := range options {
+		option.apply(scheduler)
+	}
+
+	return scheduler
+}
+
+// Stats returns the current activity of the scheduler.
+func (s *Scheduler) Stats() SchedulerStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.stats
+}
+
+// Schedule calls the specified executor every period, with a context that
+// expires after the specified timeout, and passes its results to the
+// specified callback.
+//
+// The callback is never called concurrently, nor after the returned cancel
+// function was called.
+//
+// If a probe is still running or waiting when the next one is due, the next
+// one is skipped and reported as an overrun.
+//
+// Like time.NewTicker, Schedule panics if the period is not positive.
+func (s *Scheduler) Schedule(period time.Duration, timeout time.Duration, executor Executor, callback func(Result)) (cancel func()) {
+	if period <= 0 {
+		panic(fmt.Sprintf("non-positive schedule period: %s", period))
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	schedule := &schedule{
+		scheduler: s,
+		period:    period,
+		timeout:   timeout,
+		executor:  executor,
+		callback:  callback,
+		ctx:       ctx,
+	}
+
+	s.update(func(stats *SchedulerStats) { stats.Schedules++ })
+
+	go schedule.run()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			schedule.lock.Lock()
+			schedule.cancelled = true
+			schedule.lock.Unlock()
+
+			cancelCtx()
+			s.update(func(stats *SchedulerStats) { stats.Schedules-- })
+		})
+	}
+}
+
+func (s *Scheduler) update(f func(stats *SchedulerStats)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f(&s.stats)
+}
+
+func (s *Scheduler) delay(period time.Duration) time.Duration {
+	if s.jitter <= 0 {
+		return period
+	}
+
+	s.randLock.Lock()
+	r := s.rand.Float64()
+	s.randLock.Unlock()
+
+	return time.Duration(float64(period) * (1 + s.jitter*(2*r-1)))
+}
+
+func (s *Scheduler) acquire(ctx context.Context) bool {
+	if s.slots == nil {
+		return true
+	}
+
+	s.update(func(stats *SchedulerStats) { stats.Waiting++ })
+	defer s.update(func(stats *SchedulerStats) { stats.Waiting-- })
+
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *Scheduler) release() {
+	if s.slots != nil {
+		<-s.slots
+	}
+}
+
+type schedule struct {
+	scheduler *Scheduler
+	period    time.Duration
+	timeout   time.Duration
+	executor  Executor
+	callback  func(Result)
+	ctx       context.Context
+	lock      sync.Mutex
+	cancelled bool
+}
+
+func (s *schedule) run() {
+	busy := make(chan struct{}, 1)
+	timer := time.NewTimer(s.scheduler.delay(s.period))
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			select {
+			case busy <- struct{}{}:
+				go func() {
+					s.probe()
+					<-busy
+				}()
+			default:
+				s.scheduler.update(func(stats *SchedulerStats) { stats.Overruns++ })
+			}
+
+			timer.Reset(s.scheduler.delay(s.period))
+		}
+	}
+}
+
+func (s *schedule) probe() {
+	if !s.scheduler.acquire(s.ctx) {
+		return
+	}
+
+	defer s.scheduler.release()
+
+	s.scheduler.update(func(stats *SchedulerStats) { stats.Running++ })
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	result := s.executor(ctx)
+	cancel()
+
+	s.scheduler.update(func(stats *SchedulerStats) {
+		stats.Running--
+		stats.Probes++
+	})
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.cancelled {
+		s.callback(result)
+	}
+}
@@ -0,0 +1,172 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func waitStats(t *testing.T, scheduler *Scheduler, predicate func(SchedulerStats) bool) SchedulerStats {
+	deadline := time.Now().Add(time.Second)
+
+	for {
+		stats := scheduler.Stats()
+
+		if predicate(stats) {
+			return stats
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("unexpected scheduler stats: %+v", stats)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+	scheduler := NewScheduler()
+	results := make(chan Result, 10)
+
+	cancel := scheduler.Schedule(time.Millisecond, time.Second, TrueExecutor, func(result Result) {
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		select {
+		case result := <-results:
+			if !result.OK {
+				t.Errorf("expected true, got %s", result)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a result")
+		}
+	}
+
+	if stats := scheduler.Stats(); stats.Schedules != 1 {
+		t.Errorf("expected 1 schedule, got %d", stats.Schedules)
+	}
+
+	cancel()
+	cancel()
+
+	if stats := scheduler.Stats(); stats.Schedules != 0 {
+		t.Errorf("expected no schedule, got %d", stats.Schedules)
+	}
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	scheduler := NewScheduler()
+	results := make(chan Result, 1)
+	executor := func(ctx context.Context) Result {
+		<-ctx.Done()
+		return Result{Err: ctx.Err()}
+	}
+
+	cancel := scheduler.Schedule(time.Millisecond, 5*time.Millisecond, executor, func(result Result) {
+		select {
+		case results <- result:
+		default:
+		}
+	})
+	defer cancel()
+
+	select {
+	case result := <-results:
+		if result.Err != context.DeadlineExceeded {
+			t.Errorf("expected a timeout, got %s", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a result")
+	}
+}
+
+func TestSchedulerBackpressure(t *testing.T) {
+	scheduler := NewScheduler(SchedulerConcurrencyOption{Limit: 1})
+	release := make(chan struct{})
+	executor := func(ctx context.Context) Result {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+
+		return Result{OK: true}
+	}
+
+	cancel1 := scheduler.Schedule(time.Millisecond, time.Minute, executor, func(Result) {})
+	cancel2 := scheduler.Schedule(time.Millisecond, time.Minute, executor, func(Result) {})
+
+	stats := waitStats(t, scheduler, func(stats SchedulerStats) bool {
+		return stats.Running == 1 && stats.Waiting == 1 && stats.Overruns > 0
+	})
+
+	if stats.Probes != 0 {
+		t.Errorf("expected no completed probe, got %d", stats.Probes)
+	}
+
+	close(release)
+	waitStats(t, scheduler, func(stats SchedulerStats) bool { return stats.Probes >= 2 })
+
+	cancel1()
+	cancel2()
+
+	waitStats(t, scheduler, func(stats SchedulerStats) bool {
+		return stats.Schedules == 0 && stats.Running == 0 && stats.Waiting == 0
+	})
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	scheduler := NewScheduler()
+	started := make(chan struct{}, 1)
+	called := make(chan struct{}, 1)
+	executor := func(ctx context.Context) Result {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+
+		<-ctx.Done()
+		return Result{Err: ctx.Err()}
+	}
+
+	cancel := scheduler.Schedule(time.Millisecond, time.Minute, executor, func(Result) { called <- struct{}{} })
+	<-started
+	cancel()
+
+	waitStats(t, scheduler, func(stats SchedulerStats) bool { return stats.Running == 0 })
+
+	select {
+	case <-called:
+		t.Error("expected no callback after cancellation")
+	default:
+	}
+}
+
+func TestSchedulerDelay(t *testing.T) {
+	scheduler := NewScheduler(SchedulerJitterOption{Jitter: 0.5})
+
+	for i := 0; i < 100; i++ {
+		if delay := scheduler.delay(time.Second); delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+			t.Fatalf("expected a delay within [500ms, 1.5s], got %s", delay)
+		}
+	}
+
+	scheduler = NewScheduler(SchedulerJitterOption{Jitter: 0})
+
+	if delay := scheduler.delay(time.Second); delay != time.Second {
+		t.Errorf("expected a delay of 1s, got %s", delay)
+	}
+}
+
+func TestSchedulerInvalidPeriod(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	NewScheduler().Schedule(0, time.Second, TrueExecutor, func(Result) {})
+}
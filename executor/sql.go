@@ -0,0 +1,266 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type sqlExecutor struct {
+	query      string
+	args       []interface{}
+	comparison *Comparison
+	db         *sql.DB
+	err        error
+}
+
+// SQLOption represents an option for a SQL executor.
+type SQLOption interface {
+	apply(executor *sqlExecutor)
+}
+
+// SQLArgsOption defines the arguments of the query run by a SQL executor, for
+// its placeholder parameters.
+type SQLArgsOption struct {
+	Args []interface{}
+}
+
+func (o SQLArgsOption) apply(executor *sqlExecutor) {
+	executor.args = o.Args
+}
+
+// SQLComparisonOption makes a SQL executor compare the value returned by its
+// query as a number, instead of interpreting it as a boolean.
+type SQLComparisonOption struct {
+	Comparison Comparison
+}
+
+func (o SQLComparisonOption) apply(executor *sqlExecutor) {
+	executor.comparison = &o.Comparison
+}
+
+// SQLExecutor returns an Executor that runs a query against the database
+// identified by the specified driver name and data source name.
+//
+// The driver must have been registered with database/sql, usually by
+// importing its package. For instance, SQLite databases can be queried by
+// importing a SQLite driver, like github.com/mattn/go-sqlite3.
+//
+// The executor looks at the first column of the first row only. If the query
+// returns no rows or NULL, the executor returns false. Otherwise, unless a
+// SQLComparisonOption is specified, it returns the value as a boolean, where
+// numbers are true when not zero. If the query fails or its value cannot be
+// interpreted, the executor fails.
+//
+// The result holds the returned value.
+//
+// The database is never closed. Use SQLDatabaseExecutor to control its
+// lifetime.
+func SQLExecutor(driver string, dsn string, query string, options ...SQLOption) Executor {
+	db, err := OpenSQLDatabase(driver, dsn)
+
+	return newSQLExecutor(db, err, query, options).executor()
+}
+
+// SQLDatabaseExecutor returns an Executor that runs a query against the
+// specified database, like SQLExecutor does.
+//
+// The database is not closed by the executor.
+func SQLDatabaseExecutor(db *sql.DB, query string, options ...SQLOption) Executor {
+	return newSQLExecutor(db, nil, query, options).executor()
+}
+
+// SQLNumericExecutor returns a NumericExecutor that runs a query against the
+// database identified by the specified driver name and data source name, and
+// returns the value of the first column of the first row as a number.
+//
+// The NumericExecutor fails if the query returns no rows or NULL.
+//
+// The database is never closed. Use SQLDatabaseNumericExecutor to control its
+// lifetime.
+func SQLNumericExecutor(driver string, dsn string, query string, options ...SQLOption) NumericExecutor {
+	db, err := OpenSQLDatabase(driver, dsn)
+
+	return newSQLExecutor(db, err, query, options).numericExecutor()
+}
+
+// SQLDatabaseNumericExecutor returns a NumericExecutor that runs a query
+// against the specified database, like SQLNumericExecutor does.
+//
+// The database is not closed by the executor.
+func SQLDatabaseNumericExecutor(db *sql.DB, query string, options ...SQLOption) NumericExecutor {
+	return newSQLExecutor(db, nil, query, options).numericExecutor()
+}
+
+// OpenSQLDatabase opens the database identified by the specified driver name
+// and data source name, the way SQLExecutor and SQLNumericExecutor do.
+//
+// Opening a database does not connect to it, so errors are those of the
+// driver name or data source name.
+func OpenSQLDatabase(driver string, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driver, dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+// newSQLExecutor instantiates a sqlExecutor, which reports the specified
+// error, if any, on every call.
+func newSQLExecutor(db *sql.DB, err error, query string, options []SQLOption) *sqlExecutor {
+	executor := &sqlExecutor{
+		query: query,
+		db:    db,
+		err:   err,
+	}
+
+	for _, option := range options {
+		option.apply(executor)
+	}
+
+	return executor
+}
+
+func (e *sqlExecutor) executor() Executor {
+	return func(ctx context.Context) Result {
+		start := time.Now()
+		value, err := e.run(ctx)
+		result := Result{
+			Latency: time.Since(start),
+		}
+
+		if err != nil {
+			result.Err = err
+			return result
+		}
+
+		if value != nil {
+			result.Output = truncate(fmt.Sprint(value), OutputSnippetSize)
+		}
+
+		result.OK, result.Err = e.evaluate(value)
+
+		return result
+	}
+}
+
+func (e *sqlExecutor) numericExecutor() NumericExecutor {
+	return func(ctx context.Context) (float64, error) {
+		value, err := e.run(ctx)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if value == nil {
+			return 0, fmt.Errorf("no value returned by \"%s\"", e.query)
+		}
+
+		return sqlToFloat(value)
+	}
+}
+
+// run returns the value of the first column of the first row, or nil if
+// there are no rows.
+func (e *sqlExecutor) run(ctx context.Context) (interface{}, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	rows, err := e.db.QueryContext(ctx, e.query, e.args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no column returned by \"%s\"", e.query)
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err = rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	if value, ok := values[0].([]byte); ok {
+		return string(value), nil
+	}
+
+	return values[0], nil
+}
+
+func (e *sqlExecutor) evaluate(value interface{}) (bool, error) {
+	if value == nil {
+		return false, nil
+	}
+
+	if e.comparison != nil {
+		number, err := sqlToFloat(value)
+
+		if err != nil {
+			return false, err
+		}
+
+		return e.comparison.Match(number), nil
+	}
+
+	switch value := value.(type) {
+	case bool:
+		return value, nil
+	case string:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b, nil
+		}
+	}
+
+	number, err := sqlToFloat(value)
+
+	if err != nil {
+		return false, err
+	}
+
+	return number != 0, nil
+}
+
+func sqlToFloat(value interface{}) (float64, error) {
+	if number, ok := toFloat(value); ok {
+		return number, nil
+	}
+
+	switch value := value.(type) {
+	case bool:
+		if value {
+			return 1, nil
+		}
+
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(value, 64)
+	}
+
+	return 0, fmt.Errorf("cannot interpret %v as a number", value)
+}
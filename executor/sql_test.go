@@ -0,0 +1,150 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// fakeSQLDriver answers queries from a fixed set of single-column results.
+type fakeSQLDriver map[string][]driver.Value
+
+func (d fakeSQLDriver) Open(name string) (driver.Conn, error) {
+	return fakeSQLConn(d), nil
+}
+
+type fakeSQLConn fakeSQLDriver
+
+func (c fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	values, ok := c[query]
+
+	if !ok {
+		return nil, fmt.Errorf("no such table in \"%s\"", query)
+	}
+
+	return fakeSQLStmt(values), nil
+}
+
+func (c fakeSQLConn) Close() error              { return nil }
+func (c fakeSQLConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type fakeSQLStmt []driver.Value
+
+func (s fakeSQLStmt) Close() error  { return nil }
+func (s fakeSQLStmt) NumInput() int { return -1 }
+
+func (s fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if len(args) > 0 {
+		return &fakeSQLRows{values: args}, nil
+	}
+
+	return &fakeSQLRows{values: s}, nil
+}
+
+type fakeSQLRows struct {
+	values []driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return []string{"value"} }
+func (r *fakeSQLRows) Close() error      { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+
+	return nil
+}
+
+func init() {
+	sql.Register("gotomatic-fake", fakeSQLDriver{
+		"SELECT true":      {true},
+		"SELECT false":     {false},
+		"SELECT 42":        {int64(42)},
+		"SELECT 0":         {int64(0)},
+		"SELECT 1.5":       {1.5},
+		"SELECT 'true'":    {[]byte("true")},
+		"SELECT '12'":      {"12"},
+		"SELECT 'foo'":     {"foo"},
+		"SELECT NULL":      {nil},
+		"SELECT nothing":   {},
+		"SELECT ?":         {},
+		"SELECT 1 UNION 0": {int64(1), int64(0)},
+	})
+}
+
+func TestSQLExecutor(t *testing.T) {
+	greater := SQLComparisonOption{Comparison: Comparison{Operator: OperatorGreater, Reference: 10}}
+
+	testCases := []struct {
+		Query    string
+		Options  []SQLOption
+		Expected bool
+	}{
+		{"SELECT true", nil, true},
+		{"SELECT false", nil, false},
+		{"SELECT 42", nil, true},
+		{"SELECT 0", nil, false},
+		{"SELECT 1.5", nil, true},
+		{"SELECT 'true'", nil, true},
+		{"SELECT NULL", nil, false},
+		{"SELECT nothing", nil, false},
+		{"SELECT 1 UNION 0", nil, true},
+		{"SELECT 42", []SQLOption{greater}, true},
+		{"SELECT 1.5", []SQLOption{greater}, false},
+		{"SELECT '12'", []SQLOption{greater}, true},
+		{"SELECT ?", []SQLOption{SQLArgsOption{Args: []interface{}{int64(1)}}}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Query, func(t *testing.T) {
+			value := SQLExecutor("gotomatic-fake", "", testCase.Query, testCase.Options...)(context.Background())
+
+			if !value.Known() {
+				t.Fatalf("expected a known result, got %s", value)
+			}
+
+			if value.OK != testCase.Expected {
+				t.Errorf("expected %t, got %s (%q)", testCase.Expected, value, value.Output)
+			}
+		})
+	}
+
+	for _, query := range []string{"SELECT 'foo'", "SELECT * FROM missing"} {
+		if value := SQLExecutor("gotomatic-fake", "", query)(context.Background()); value.Known() {
+			t.Errorf("expected an unknown result for %s, got %s", query, value)
+		}
+	}
+
+	if value := SQLExecutor("unknown-driver", "", "SELECT true")(context.Background()); value.Known() {
+		t.Errorf("expected an unknown result for an unknown driver, got %s", value)
+	}
+}
+
+func TestSQLNumericExecutor(t *testing.T) {
+	value, err := SQLNumericExecutor("gotomatic-fake", "", "SELECT 42")(context.Background())
+
+	if err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if value != 42 {
+		t.Errorf("expected 42, got %v", value)
+	}
+
+	for _, query := range []string{"SELECT NULL", "SELECT nothing", "SELECT 'foo'"} {
+		if _, err := SQLNumericExecutor("gotomatic-fake", "", query)(context.Background()); err == nil {
+			t.Errorf("expected an error for %s", query)
+		}
+	}
+}
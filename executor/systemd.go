@@ -0,0 +1,65 @@
+package executor
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// A SystemdBackend queries the state of systemd units.
+type SystemdBackend interface {
+	// ActiveState returns the active state of the specified unit, like
+	// "active", "inactive" or "failed".
+	ActiveState(ctx context.Context, unit string) (string, error)
+}
+
+// SystemctlBackend is a SystemdBackend that runs systemctl.
+type SystemctlBackend struct {
+	// User makes the backend query the user service manager instead of the
+	// system one.
+	User bool
+}
+
+// ActiveState returns the active state of the specified unit.
+func (b SystemctlBackend) ActiveState(ctx context.Context, unit string) (string, error) {
+	args := []string{"show", "--property=ActiveState", "--value"}
+
+	if b.User {
+		args = append(args, "--user")
+	}
+
+	output, err := exec.CommandContext(ctx, "systemctl", append(args, "--", unit)...).Output()
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// SystemdExecutor returns an Executor that is true whenever the specified
+// unit is in one of the specified active states, or "active" if none is
+// specified.
+//
+// The executor fails if the backend fails.
+func SystemdExecutor(backend SystemdBackend, unit string, states ...string) Executor {
+	if len(states) == 0 {
+		states = []string{"active"}
+	}
+
+	return func(ctx context.Context) Result {
+		state, err := backend.ActiveState(ctx, unit)
+
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		for _, expected := range states {
+			if state == expected {
+				return Result{OK: true, Output: state}
+			}
+		}
+
+		return Result{OK: false, Output: state}
+	}
+}
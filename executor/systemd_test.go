@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeSystemdBackend is a SystemdBackend whose unit states are fixed.
+//
+// Units without a state are inactive.
+type fakeSystemdBackend map[string]string
+
+func (b fakeSystemdBackend) ActiveState(ctx context.Context, unit string) (string, error) {
+	if state, ok := b[unit]; ok {
+		return state, nil
+	}
+
+	return "inactive", nil
+}
+
+type failingSystemdBackend struct{}
+
+func (failingSystemdBackend) ActiveState(ctx context.Context, unit string) (string, error) {
+	return "", errors.New("fail")
+}
+
+func TestSystemdExecutor(t *testing.T) {
+	backend := fakeSystemdBackend{
+		"nginx.service":  "active",
+		"backup.service": "activating",
+	}
+
+	testCases := []struct {
+		Unit     string
+		States   []string
+		Expected bool
+	}{
+		{"nginx.service", nil, true},
+		{"backup.service", nil, false},
+		{"backup.service", []string{"active", "activating"}, true},
+		{"missing.service", nil, false},
+		{"missing.service", []string{"inactive"}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Unit, func(t *testing.T) {
+			value := SystemdExecutor(backend, testCase.Unit, testCase.States...)(context.Background())
+
+			if !value.Known() || value.OK != testCase.Expected {
+				t.Errorf("expected %t, got %s", testCase.Expected, value)
+			}
+		})
+	}
+
+	if value := SystemdExecutor(failingSystemdBackend{}, "nginx.service")(context.Background()); value.Known() {
+		t.Errorf("expected an unknown result, got %s", value)
+	}
+}
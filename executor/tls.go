@@ -0,0 +1,16 @@
+package executor
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+// certificateValidFor tells whether the leaf certificate of the specified
+// connection remains valid for at least the specified duration.
+func certificateValidFor(state *tls.ConnectionState, validity time.Duration, now time.Time) bool {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return false
+	}
+
+	return !now.Add(validity).After(state.PeerCertificates[0].NotAfter)
+}
@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateClientCertificate(t *testing.T) (tls.Certificate, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, certificate
+}
+
+func TestHTTPExecutorTLS(t *testing.T) {
+	clientCertificate, clientCA := generateClientCertificate(t)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	ts.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  x509.NewCertPool(),
+	}
+	ts.TLS.ClientCAs.AddCert(clientCA)
+	ts.StartTLS()
+	defer ts.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+
+	config := &tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{clientCertificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	value := HTTPExecutor("GET", ts.URL, []int{200}, time.Second, HTTPTLSOption{Config: config})(context.Background())
+
+	if !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	value = HTTPExecutor("GET", ts.URL, []int{200}, time.Second, HTTPTLSOption{Config: &tls.Config{RootCAs: roots}})(context.Background())
+
+	if value.OK {
+		t.Errorf("expected a failure without a client certificate, got %s", value)
+	}
+
+	value = HTTPExecutor("GET", ts.URL, []int{200}, time.Second)(context.Background())
+
+	if value.OK {
+		t.Errorf("expected a failure for an untrusted certificate, got %s", value)
+	}
+}
+
+func TestHTTPExecutorCertificateValidity(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer ts.Close()
+
+	config := &tls.Config{InsecureSkipVerify: true}
+	notAfter := ts.Certificate().NotAfter
+
+	value := HTTPExecutor("GET", ts.URL, []int{200}, time.Second, HTTPTLSOption{Config: config}, HTTPCertificateValidityOption{Validity: time.Hour})(context.Background())
+
+	if !value.OK {
+		t.Errorf("expected true, got %s", value)
+	}
+
+	validity := notAfter.Sub(time.Now()) + time.Hour
+	value = HTTPExecutor("GET", ts.URL, []int{200}, time.Second, HTTPTLSOption{Config: config}, HTTPCertificateValidityOption{Validity: validity})(context.Background())
+
+	if value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+
+	address := ts.Listener.Addr().String()
+	value = DialExecutor("tcp", address, DialTLSOption{Config: config}, DialCertificateValidityOption{Validity: validity})(context.Background())
+
+	if value.OK || !value.Known() {
+		t.Errorf("expected false, got %s", value)
+	}
+
+	value = DialExecutor("tcp", address, DialCertificateValidityOption{Validity: time.Hour})(context.Background())
+
+	if value.OK || !value.Known() {
+		t.Errorf("expected false without TLS, got %s", value)
+	}
+}
+
+func TestCertificateValidFor(t *testing.T) {
+	now := time.Now()
+	state := &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{NotAfter: now.Add(48 * time.Hour)}},
+	}
+
+	if !certificateValidFor(state, 24*time.Hour, now) {
+		t.Error("expected true")
+	}
+
+	if certificateValidFor(state, 72*time.Hour, now) {
+		t.Error("expected false")
+	}
+
+	if certificateValidFor(nil, time.Hour, now) {
+		t.Error("expected false without a connection state")
+	}
+}
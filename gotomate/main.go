@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,7 +12,11 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/intelux/gotomatic/conditional"
 	"github.com/intelux/gotomatic/configuration"
+	"github.com/intelux/gotomatic/trigger"
 	"github.com/spf13/cobra"
+
+	// SQL drivers available to the sql executors.
+	_ "github.com/mattn/go-sqlite3"
 )
 
 var (
@@ -28,15 +31,7 @@ var rootCmd = &cobra.Command{
 		var config configuration.Configuration
 
 		if configFile != "" {
-			var f io.ReadCloser
-
-			if f, err = os.Open(configFile); err != nil {
-				return err
-			}
-
-			defer f.Close()
-
-			if config, err = configuration.Load(f); err != nil {
+			if config, err = configuration.LoadFile(configFile); err != nil {
 				return err
 			}
 		} else {
@@ -47,8 +42,12 @@ var rootCmd = &cobra.Command{
 
 		r := mux.NewRouter()
 		r.Methods("GET").Path("/conditions/{name}").HandlerFunc(GetConditionHandler(config))
+		r.Methods("GET").Path("/conditions/{name}/result").HandlerFunc(GetConditionResultHandler(config))
 		r.Methods("POST").Path("/conditions/{name}").HandlerFunc(WaitConditionHandler(config))
 		r.Methods("PUT").Path("/conditions/{name}").HandlerFunc(SetConditionHandler(config))
+		r.Methods("GET").Path("/variables/{name}").HandlerFunc(GetVariableHandler(config))
+		r.Methods("PUT").Path("/variables/{name}").HandlerFunc(SetVariableHandler(config))
+		r.Methods("GET").Path("/scheduler").HandlerFunc(GetSchedulerHandler(config))
 
 		stop := make(chan os.Signal)
 		defer close(stop)
@@ -73,6 +72,10 @@ var rootCmd = &cobra.Command{
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
 
+		ctx = trigger.WithErrorHandler(ctx, func(err error) {
+			fmt.Fprintf(os.Stderr, "Trigger error: %s\n", err)
+		})
+
 		go func() {
 			if err := config.Watch(ctx); err != nil {
 				errorCh <- err
@@ -117,6 +120,53 @@ func GetConditionHandler(config configuration.Configuration) http.HandlerFunc {
 	}
 }
 
+type resultResponse struct {
+	OK         bool   `json:"ok"`
+	Known      bool   `json:"known"`
+	Error      string `json:"error,omitempty"`
+	ExitCode   int    `json:"exitCode"`
+	StatusCode int    `json:"statusCode"`
+	Latency    string `json:"latency"`
+	Output     string `json:"output"`
+}
+
+func GetConditionResultHandler(config configuration.Configuration) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := mux.Vars(req)["name"]
+		condition := config.GetCondition(name)
+
+		if condition == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		result, ok := conditional.LastResultOf(condition)
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "condition has no executor result\n")
+			return
+		}
+
+		response := resultResponse{
+			OK:         result.OK,
+			Known:      result.Known(),
+			ExitCode:   result.ExitCode,
+			StatusCode: result.StatusCode,
+			Latency:    result.Latency.String(),
+			Output:     result.Output,
+		}
+
+		if result.Err != nil {
+			response.Error = result.Err.Error()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
 func WaitConditionHandler(config configuration.Configuration) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		name := mux.Vars(req)["name"]
@@ -182,6 +232,71 @@ func SetConditionHandler(config configuration.Configuration) http.HandlerFunc {
 	}
 }
 
+func GetVariableHandler(config configuration.Configuration) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := mux.Vars(req)["name"]
+		variable := config.GetVariable(name)
+
+		if variable == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(variable.Get())
+	}
+}
+
+func SetVariableHandler(config configuration.Configuration) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := mux.Vars(req)["name"]
+		variable := config.GetVariable(name)
+
+		if variable == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var value float64
+
+		if err := json.NewDecoder(req.Body).Decode(&value); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s\n", fmt.Errorf("JSON decoding error: %s", err))
+			return
+		}
+
+		variable.Set(value)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+type schedulerResponse struct {
+	Schedules int    `json:"schedules"`
+	Running   int    `json:"running"`
+	Waiting   int    `json:"waiting"`
+	Probes    uint64 `json:"probes"`
+	Overruns  uint64 `json:"overruns"`
+}
+
+func GetSchedulerHandler(config configuration.Configuration) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		stats := config.Scheduler().Stats()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(schedulerResponse{
+			Schedules: stats.Schedules,
+			Running:   stats.Running,
+			Waiting:   stats.Waiting,
+			Probes:    stats.Probes,
+			Overruns:  stats.Overruns,
+		})
+	}
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
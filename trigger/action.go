@@ -1,6 +1,9 @@
 package trigger
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Action represents an action performed by a Trigger.
 type Action interface {
@@ -12,6 +15,13 @@ type actionKey int
 const (
 	conditionNameKey actionKey = iota
 	conditionStateKey
+	transitionTimeKey
+	previousStateDurationKey
+	conditionGetterKey
+	sequenceNumberKey
+	configurationFileKey
+	errorHandlerKey
+	stateStoreKey
 )
 
 // WithConditionName injects a condition name in the specified context.
@@ -41,3 +51,82 @@ func GetConditionState(ctx context.Context) *bool {
 
 	return nil
 }
+
+// WithTransitionTime injects the time of a condition state change in the
+// specified context.
+func WithTransitionTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, transitionTimeKey, t)
+}
+
+// GetTransitionTime gets the time of a condition state change from a context.
+func GetTransitionTime(ctx context.Context) *time.Time {
+	if t, ok := ctx.Value(transitionTimeKey).(time.Time); ok {
+		return &t
+	}
+
+	return nil
+}
+
+// WithPreviousStateDuration injects the duration of the previous state of a
+// condition in the specified context.
+func WithPreviousStateDuration(ctx context.Context, duration time.Duration) context.Context {
+	return context.WithValue(ctx, previousStateDurationKey, duration)
+}
+
+// GetPreviousStateDuration gets the duration of the previous state of a
+// condition from a context.
+func GetPreviousStateDuration(ctx context.Context) *time.Duration {
+	if duration, ok := ctx.Value(previousStateDurationKey).(time.Duration); ok {
+		return &duration
+	}
+
+	return nil
+}
+
+// WithConditionGetter injects a condition getter in the specified context.
+//
+// Templates use it to access the states of other named conditions.
+func WithConditionGetter(ctx context.Context, getter ConditionGetter) context.Context {
+	return context.WithValue(ctx, conditionGetterKey, getter)
+}
+
+// GetConditionGetter gets the condition getter from a context.
+func GetConditionGetter(ctx context.Context) ConditionGetter {
+	getter, _ := ctx.Value(conditionGetterKey).(ConditionGetter)
+
+	return getter
+}
+
+// WithSequenceNumber injects the sequence number of a condition state change
+// in the specified context.
+//
+// Each watch numbers the state changes it acts upon, starting at 1.
+func WithSequenceNumber(ctx context.Context, sequence uint64) context.Context {
+	return context.WithValue(ctx, sequenceNumberKey, sequence)
+}
+
+// GetSequenceNumber gets the sequence number of a condition state change from
+// a context.
+func GetSequenceNumber(ctx context.Context) *uint64 {
+	if sequence, ok := ctx.Value(sequenceNumberKey).(uint64); ok {
+		return &sequence
+	}
+
+	return nil
+}
+
+// WithConfigurationFile injects the path of the configuration file that
+// declared a trigger in the specified context.
+func WithConfigurationFile(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, configurationFileKey, path)
+}
+
+// GetConfigurationFile gets the path of the configuration file that declared
+// a trigger from a context.
+func GetConfigurationFile(ctx context.Context) *string {
+	if path, ok := ctx.Value(configurationFileKey).(string); ok {
+		return &path
+	}
+
+	return nil
+}
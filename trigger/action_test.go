@@ -3,6 +3,7 @@ package trigger
 import (
 	"context"
 	"testing"
+	"time"
 )
 
 func TestWithConditionName(t *testing.T) {
@@ -32,3 +33,59 @@ func TestWithConditionState(t *testing.T) {
 		t.Errorf("expected a state")
 	}
 }
+
+func TestWithTransitionTime(t *testing.T) {
+	ctx := context.Background()
+
+	if tt := GetTransitionTime(ctx); tt != nil {
+		t.Errorf("expected no time, but got: %s", *tt)
+	}
+
+	ctx = WithTransitionTime(ctx, time.Now())
+
+	if tt := GetTransitionTime(ctx); tt == nil {
+		t.Errorf("expected a time")
+	}
+}
+
+func TestWithPreviousStateDuration(t *testing.T) {
+	ctx := context.Background()
+
+	if duration := GetPreviousStateDuration(ctx); duration != nil {
+		t.Errorf("expected no duration, but got: %s", *duration)
+	}
+
+	ctx = WithPreviousStateDuration(ctx, time.Second)
+
+	if duration := GetPreviousStateDuration(ctx); duration == nil {
+		t.Errorf("expected a duration")
+	}
+}
+
+func TestWithSequenceNumber(t *testing.T) {
+	ctx := context.Background()
+
+	if sequence := GetSequenceNumber(ctx); sequence != nil {
+		t.Errorf("expected no sequence number, but got: %d", *sequence)
+	}
+
+	ctx = WithSequenceNumber(ctx, 1)
+
+	if sequence := GetSequenceNumber(ctx); sequence == nil {
+		t.Errorf("expected a sequence number")
+	}
+}
+
+func TestWithConfigurationFile(t *testing.T) {
+	ctx := context.Background()
+
+	if path := GetConfigurationFile(ctx); path != nil {
+		t.Errorf("expected no path, but got: %s", *path)
+	}
+
+	ctx = WithConfigurationFile(ctx, "foo.yaml")
+
+	if path := GetConfigurationFile(ctx); path == nil {
+		t.Errorf("expected a path")
+	}
+}
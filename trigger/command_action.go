@@ -5,9 +5,16 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
+	"text/template"
+	"time"
 )
 
+// commandWaitDelay bounds the wait for the outputs of a command after its
+// context expired, in case some of its child processes keep them open.
+const commandWaitDelay = 500 * time.Millisecond
+
 type commandAction struct {
 	cmd  string
 	args []string
@@ -16,12 +23,21 @@ type commandAction struct {
 
 // NewCommandAction instantiates a new action that executes a command.
 //
-// Two environment variables are added before the command gets executed:
+// The following environment variables are added before the command gets
+// executed, when the information is available:
 //
 // - `GOTOMATIC_CONDITION_NAME`: The name of the condition whose state changed,
 // if it has one.
 //
 // - `GOTOMATIC_CONDITION_STATE`: The state of the condition, as 0 or 1.
+//
+// - `GOTOMATIC_TRANSITION_TIME`: The time of the state change, in RFC 3339
+// format.
+//
+// - `GOTOMATIC_SEQUENCE_NUMBER`: The sequence number of the state change.
+//
+// - `GOTOMATIC_CONFIGURATION_FILE`: The path of the configuration file that
+// declared the trigger.
 func NewCommandAction(cmd string, args []string, env []string) Action {
 	return &commandAction{
 		cmd:  cmd,
@@ -31,8 +47,71 @@ func NewCommandAction(cmd string, args []string, env []string) Action {
 }
 
 func (t *commandAction) run(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, t.cmd, t.args...)
-	cmd.Env = t.env
+	return runCommand(ctx, t.cmd, t.args, t.env)
+}
+
+type commandTemplateAction struct {
+	cmd         *template.Template
+	args        []*template.Template
+	env         []string
+	envTemplate map[string]*template.Template
+}
+
+// NewCommandTemplateAction instantiates a new action that executes a command
+// whose name, arguments and additional environment variables are templates.
+//
+// The templates are executed with a TemplateData whenever the action runs,
+// and the environment variables they generate are added to the specified
+// environment, in addition to the ones of NewCommandAction.
+func NewCommandTemplateAction(cmd *template.Template, args []*template.Template, env []string, envTemplate map[string]*template.Template) Action {
+	return &commandTemplateAction{
+		cmd:         cmd,
+		args:        args,
+		env:         env,
+		envTemplate: envTemplate,
+	}
+}
+
+func (t *commandTemplateAction) run(ctx context.Context) error {
+	cmd, err := executeTemplate(ctx, t.cmd)
+
+	if err != nil {
+		return fmt.Errorf("generating the command: %s", err)
+	}
+
+	args, err := executeTemplates(ctx, t.args)
+
+	if err != nil {
+		return fmt.Errorf("generating the arguments of \"%s\": %s", cmd, err)
+	}
+
+	keys := make([]string, 0, len(t.envTemplate))
+
+	for key := range t.envTemplate {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	env := append([]string{}, t.env...)
+
+	for _, key := range keys {
+		value, err := executeTemplate(ctx, t.envTemplate[key])
+
+		if err != nil {
+			return fmt.Errorf("generating the environment variable %s of \"%s\": %s", key, cmd, err)
+		}
+
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	return runCommand(ctx, string(cmd), args, env)
+}
+
+func runCommand(ctx context.Context, name string, args []string, env []string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.WaitDelay = commandWaitDelay
 
 	if name := GetConditionName(ctx); name != nil {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("GOTOMATIC_CONDITION_NAME=%s", *name))
@@ -48,12 +127,24 @@ func (t *commandAction) run(ctx context.Context) error {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("GOTOMATIC_CONDITION_STATE=%d", stateInt))
 	}
 
+	if t := GetTransitionTime(ctx); t != nil {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GOTOMATIC_TRANSITION_TIME=%s", t.Format(time.RFC3339Nano)))
+	}
+
+	if sequence := GetSequenceNumber(ctx); sequence != nil {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GOTOMATIC_SEQUENCE_NUMBER=%d", *sequence))
+	}
+
+	if path := GetConfigurationFile(ctx); path != nil {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GOTOMATIC_CONFIGURATION_FILE=%s", *path))
+	}
+
 	output := &bytes.Buffer{}
 	cmd.Stderr = output
 	cmd.Stdout = output
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("executing \"%s %s\": %s\nOutput was:\n%s", t.cmd, strings.Join(t.args, " "), err, output)
+		return fmt.Errorf("executing \"%s %s\": %s\nOutput was:\n%s", name, strings.Join(args, " "), err, output)
 	}
 
 	return nil
@@ -2,8 +2,11 @@ package trigger
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"text/template"
 )
 
 func TestCommandAction(t *testing.T) {
@@ -17,3 +20,43 @@ func TestCommandAction(t *testing.T) {
 		t.Errorf("expected no error but got: %s", err)
 	}
 }
+
+func TestCommandTemplateAction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "out")
+	cmd, _ := NewTemplate("command", "{{if .State}}sh{{else}}false{{end}}")
+	script, _ := NewTemplate("args[0]", "-c")
+	body, _ := NewTemplate("args[1]", `echo "{{.Name}} $FOO" > `+path)
+	foo, _ := NewTemplate("FOO", "{{.State}}")
+
+	action := NewCommandTemplateAction(cmd, []*template.Template{script, body}, os.Environ(), map[string]*template.Template{"FOO": foo})
+	ctx := context.Background()
+	ctx = WithConditionName(ctx, "door")
+	ctx = WithConditionState(ctx, true)
+
+	if err = action.run(ctx); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if data, _ := ioutil.ReadFile(path); string(data) != "door true\n" {
+		t.Errorf("expected %q, got %q", "door true\n", data)
+	}
+
+	if err = action.run(WithConditionState(ctx, false)); err == nil {
+		t.Error("expected an error")
+	}
+
+	invalid, _ := NewTemplate("FOO", "{{.Missing}}")
+	action = NewCommandTemplateAction(cmd, nil, nil, map[string]*template.Template{"FOO": invalid})
+
+	if err = action.run(ctx); err == nil {
+		t.Error("expected an error")
+	}
+}
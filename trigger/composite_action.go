@@ -0,0 +1,149 @@
+package trigger
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ActionErrors represents the errors of several actions that failed.
+type ActionErrors []error
+
+// Error returns the errors messages, separated by semicolons.
+func (e ActionErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+type sequenceAction struct {
+	actions         []Action
+	continueOnError bool
+}
+
+// Sequence returns an action that runs the specified actions one after the
+// other, stopping at the first failure.
+func Sequence(actions ...Action) Action {
+	return sequenceAction{actions: actions}
+}
+
+// SequenceAll returns an action that runs the specified actions one after the
+// other, regardless of their failures.
+//
+// If any action fails, the returned action fails with ActionErrors.
+func SequenceAll(actions ...Action) Action {
+	return sequenceAction{actions: actions, continueOnError: true}
+}
+
+func (t sequenceAction) run(ctx context.Context) error {
+	var errs ActionErrors
+
+	for _, action := range t.actions {
+		if err := action.run(ctx); err != nil {
+			if !t.continueOnError {
+				return err
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type parallelAction struct {
+	actions  []Action
+	failFast bool
+}
+
+// Parallel returns an action that runs the specified actions concurrently and
+// waits for all of them to complete.
+//
+// If any action fails, the returned action fails with ActionErrors.
+func Parallel(actions ...Action) Action {
+	return parallelAction{actions: actions}
+}
+
+// ParallelFailFast returns an action that runs the specified actions
+// concurrently and fails as soon as one of them does.
+//
+// The context of the remaining actions gets cancelled upon the first failure,
+// and they are waited for before the returned action fails with that first
+// error.
+func ParallelFailFast(actions ...Action) Action {
+	return parallelAction{actions: actions, failFast: true}
+}
+
+func (t parallelAction) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan error, len(t.actions))
+	wg := sync.WaitGroup{}
+
+	for _, action := range t.actions {
+		wg.Add(1)
+
+		go func(action Action) {
+			defer wg.Done()
+
+			ch <- action.run(ctx)
+		}(action)
+	}
+
+	var errs ActionErrors
+
+	for range t.actions {
+		if err := <-ch; err != nil {
+			if t.failFast && len(errs) == 0 {
+				cancel()
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if t.failFast {
+		return errs[0]
+	}
+
+	return errs
+}
+
+type waitAction struct {
+	duration time.Duration
+}
+
+// Wait returns an action that waits for the specified duration.
+//
+// If the context expires first, the action fails with the context error.
+func Wait(duration time.Duration) Action {
+	return waitAction{duration: duration}
+}
+
+func (t waitAction) run(ctx context.Context) error {
+	timer := time.NewTimer(t.duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,128 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	lock  sync.Mutex
+	calls []string
+}
+
+func (r *recorder) action(name string, err error) Action {
+	return FuncAction(func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		r.calls = append(r.calls, name)
+
+		return err
+	})
+}
+
+func (r *recorder) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.calls)
+}
+
+func TestSequence(t *testing.T) {
+	r := &recorder{}
+	fail := errors.New("fail")
+	action := Sequence(r.action("a", nil), r.action("b", fail), r.action("c", nil))
+
+	if err := action.run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+
+	if len(r.calls) != 2 || r.calls[0] != "a" || r.calls[1] != "b" {
+		t.Errorf("expected a and b to be called, got %v", r.calls)
+	}
+}
+
+func TestSequenceAll(t *testing.T) {
+	r := &recorder{}
+	action := SequenceAll(r.action("a", errors.New("a")), r.action("b", nil), r.action("c", errors.New("c")))
+	err := action.run(context.Background())
+
+	if err == nil || err.Error() != "a; c" {
+		t.Errorf("expected \"a; c\", got %v", err)
+	}
+
+	if len(r.calls) != 3 {
+		t.Errorf("expected all actions to be called, got %v", r.calls)
+	}
+
+	if err := SequenceAll(r.action("d", nil)).run(context.Background()); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+}
+
+func TestParallel(t *testing.T) {
+	r := &recorder{}
+	slow := FuncAction(func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+
+		return r.action("slow", nil).run(ctx)
+	})
+	action := Parallel(r.action("a", errors.New("fail")), slow)
+
+	if err := action.run(context.Background()); err == nil {
+		t.Error("expected an error but didn't get one")
+	}
+
+	if count := r.count(); count != 2 {
+		t.Errorf("expected all actions to complete, got %d", count)
+	}
+
+	if err := Parallel(r.action("b", nil), r.action("c", nil)).run(context.Background()); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+}
+
+func TestParallelFailFast(t *testing.T) {
+	fail := errors.New("fail")
+	done := make(chan error, 1)
+	blocking := FuncAction(func(ctx context.Context) error {
+		<-ctx.Done()
+		done <- ctx.Err()
+
+		return ctx.Err()
+	})
+	failing := FuncAction(func(ctx context.Context) error { return fail })
+
+	if err := ParallelFailFast(blocking, failing).run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("expected a cancellation, got %v", err)
+		}
+	default:
+		t.Error("expected the remaining action to be cancelled and waited for")
+	}
+
+	if err := ParallelFailFast(failing, FuncAction(func(ctx context.Context) error { return nil })).run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+}
+
+func TestWait(t *testing.T) {
+	if err := Wait(time.Millisecond).run(context.Background()); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := Wait(time.Hour).run(ctx); err != context.Canceled {
+		t.Errorf("expected a cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ConcurrencyPolicy defines what happens to the state changes that occur
+// while the action of a trigger is running.
+type ConcurrencyPolicy int
+
+const (
+	// ConcurrencyQueue queues the state changes: their actions run in order,
+	// once the running one returns.
+	ConcurrencyQueue ConcurrencyPolicy = iota
+
+	// ConcurrencyCancel cancels the context of the running action, and runs
+	// the action of the latest state change once it returns. The error of the
+	// cancelled action is ignored.
+	ConcurrencyCancel
+
+	// ConcurrencySkip ignores the state changes entirely: their actions never
+	// run.
+	ConcurrencySkip
+
+	// ConcurrencyCoalesce only keeps the latest state change: its action runs
+	// once the running one returns, unless that state is the one the running
+	// action was for.
+	ConcurrencyCoalesce
+)
+
+func (p ConcurrencyPolicy) String() string {
+	switch p {
+	case ConcurrencyCancel:
+		return "cancel"
+	case ConcurrencySkip:
+		return "skip"
+	case ConcurrencyCoalesce:
+		return "coalesce"
+	}
+
+	return "queue"
+}
+
+var errTriggerDisabled = errors.New("trigger disabled")
+
+type job struct {
+	ctx   context.Context
+	state bool
+}
+
+// A dispatcher runs the jobs of a trigger one at a time, in a separate
+// goroutine, according to its concurrency policy.
+type dispatcher struct {
+	trigger Trigger
+	errors  chan error
+	lock    sync.Mutex
+	wg      sync.WaitGroup
+	queue   []job
+	running bool
+	stopped bool
+	cancel  context.CancelFunc
+	last    *bool
+}
+
+func newDispatcher(trigger Trigger) *dispatcher {
+	return &dispatcher{
+		trigger: trigger,
+		errors:  make(chan error, 1),
+	}
+}
+
+func (d *dispatcher) dispatch(j job) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.stopped {
+		return
+	}
+
+	switch d.trigger.Concurrency {
+	case ConcurrencyCancel:
+		if d.cancel != nil {
+			d.cancel()
+		}
+
+		d.queue = []job{j}
+	case ConcurrencySkip:
+		if d.running {
+			return
+		}
+
+		d.queue = []job{j}
+	case ConcurrencyCoalesce:
+		d.queue = []job{j}
+	default:
+		d.queue = append(d.queue, j)
+	}
+
+	if !d.running {
+		d.running = true
+		d.wg.Add(1)
+
+		go d.run()
+	}
+}
+
+func (d *dispatcher) next() (j job, ctx context.Context, ok bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for len(d.queue) > 0 {
+		j, d.queue = d.queue[0], d.queue[1:]
+
+		if d.trigger.Concurrency == ConcurrencyCoalesce && d.last != nil && *d.last == j.state {
+			continue
+		}
+
+		ctx, d.cancel = context.WithCancel(j.ctx)
+		d.last = &j.state
+
+		return j, ctx, true
+	}
+
+	d.running = false
+	d.cancel = nil
+
+	return j, nil, false
+}
+
+func (d *dispatcher) run() {
+	defer d.wg.Done()
+
+	for {
+		j, ctx, ok := d.next()
+
+		if !ok {
+			return
+		}
+
+		err := d.trigger.execute(ctx, j)
+
+		d.lock.Lock()
+		d.cancel()
+		d.lock.Unlock()
+
+		if err != nil {
+			d.lock.Lock()
+			d.stopped = true
+			d.running = false
+			d.queue = nil
+			d.lock.Unlock()
+
+			d.errors <- err
+
+			return
+		}
+	}
+}
+
+// wait for the running action, if any, to return.
+func (d *dispatcher) wait() {
+	d.wg.Wait()
+}
@@ -0,0 +1,138 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func TestWatchConcurrency(t *testing.T) {
+	testCases := []struct {
+		Policy    ConcurrencyPolicy
+		Expected  []bool
+		Cancelled bool
+	}{
+		{ConcurrencyQueue, []bool{false, true, false, true}, false},
+		{ConcurrencyCancel, []bool{false, true}, true},
+		{ConcurrencySkip, []bool{false}, false},
+		{ConcurrencyCoalesce, []bool{false, true}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Policy.String(), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var reported []error
+			ctx = WithErrorHandler(ctx, func(err error) { reported = append(reported, err) })
+
+			condition := conditional.NewManualCondition(false)
+			defer condition.Close()
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			cancelled := make(chan bool, 1)
+			calls := make(chan bool, 10)
+
+			first := true
+			action := FuncAction(func(ctx context.Context) error {
+				calls <- *GetConditionState(ctx)
+
+				if first {
+					first = false
+					close(started)
+
+					select {
+					case <-release:
+						cancelled <- false
+					case <-ctx.Done():
+						cancelled <- true
+						return ctx.Err()
+					}
+				}
+
+				return nil
+			})
+
+			done := make(chan error)
+			go func() {
+				done <- Watch(ctx, condition, Trigger{
+					Up:          action,
+					Down:        action,
+					OnError:     ErrorLog,
+					Concurrency: testCase.Policy,
+				})
+			}()
+
+			<-started
+			condition.Set(true)
+			condition.Set(false)
+			condition.Set(true)
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+
+			if c := <-cancelled; c != testCase.Cancelled {
+				t.Errorf("expected cancelled to be %t, got %t", testCase.Cancelled, c)
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+
+			if err := <-done; err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			close(calls)
+			var states []bool
+
+			for state := range calls {
+				states = append(states, state)
+			}
+
+			if len(states) != len(testCase.Expected) {
+				t.Fatalf("expected %v, got %v", testCase.Expected, states)
+			}
+
+			for i, state := range states {
+				if state != testCase.Expected[i] {
+					t.Errorf("expected %v, got %v", testCase.Expected, states)
+					break
+				}
+			}
+
+			if len(reported) != 0 {
+				t.Errorf("expected no reported errors, got %v", reported)
+			}
+		})
+	}
+}
+
+func TestWatchConcurrencyFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	condition := conditional.NewManualCondition(false)
+	defer condition.Close()
+
+	calls := 0
+	action := FuncAction(func(ctx context.Context) error {
+		calls++
+		time.Sleep(10 * time.Millisecond)
+		return context.DeadlineExceeded
+	})
+
+	done := make(chan error)
+	go func() { done <- Watch(ctx, condition, Trigger{Up: action, Down: action}) }()
+
+	condition.Set(true)
+
+	if err := <-done; err == nil {
+		t.Error("expected an error")
+	}
+
+	if calls != 1 {
+		t.Errorf("expected queued actions not to run after a failure, got %d calls", calls)
+	}
+}
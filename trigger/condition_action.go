@@ -0,0 +1,127 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+// A ConditionGetter gets conditions by name.
+//
+// A configuration.Configuration is a ConditionGetter.
+type ConditionGetter interface {
+	GetCondition(name string) conditional.Condition
+}
+
+type conditionAction struct {
+	getter ConditionGetter
+	name   string
+}
+
+func (t conditionAction) settable() (conditional.Condition, conditional.Settable, error) {
+	condition := t.getter.GetCondition(t.name)
+
+	if condition == nil {
+		return nil, nil, fmt.Errorf("no condition found with the name \"%s\"", t.name)
+	}
+
+	settable, ok := condition.(conditional.Settable)
+
+	if !ok {
+		return nil, nil, fmt.Errorf("condition \"%s\" cannot be set", t.name)
+	}
+
+	return condition, settable, nil
+}
+
+type setConditionAction struct {
+	conditionAction
+	state bool
+}
+
+// NewSetConditionAction instantiates a new action that sets the named
+// condition to the specified state.
+//
+// The condition is looked up whenever the action runs, and must be a
+// conditional.Settable.
+func NewSetConditionAction(getter ConditionGetter, name string, state bool) Action {
+	return setConditionAction{
+		conditionAction: conditionAction{getter: getter, name: name},
+		state:           state,
+	}
+}
+
+func (t setConditionAction) run(ctx context.Context) error {
+	_, settable, err := t.settable()
+
+	if err != nil {
+		return err
+	}
+
+	settable.Set(t.state)
+
+	return nil
+}
+
+type toggleConditionAction struct {
+	conditionAction
+}
+
+// NewToggleConditionAction instantiates a new action that toggles the state
+// of the named condition.
+//
+// The condition is looked up whenever the action runs, and must be a
+// conditional.Settable. An unknown condition gets set.
+func NewToggleConditionAction(getter ConditionGetter, name string) Action {
+	return toggleConditionAction{
+		conditionAction: conditionAction{getter: getter, name: name},
+	}
+}
+
+func (t toggleConditionAction) run(ctx context.Context) error {
+	condition, settable, err := t.settable()
+
+	if err != nil {
+		return err
+	}
+
+	settable.Set(conditional.StateOf(condition) != conditional.StateTrue)
+
+	return nil
+}
+
+type pulseConditionAction struct {
+	conditionAction
+	state    bool
+	duration time.Duration
+}
+
+// NewPulseConditionAction instantiates a new action that sets the named
+// condition to the specified state for the specified duration, and then to
+// the opposite state.
+//
+// The condition is looked up whenever the action runs, and must be a
+// conditional.Settable. If the context expires during the pulse, the
+// condition is set to the opposite state right away and the action fails.
+func NewPulseConditionAction(getter ConditionGetter, name string, state bool, duration time.Duration) Action {
+	return pulseConditionAction{
+		conditionAction: conditionAction{getter: getter, name: name},
+		state:           state,
+		duration:        duration,
+	}
+}
+
+func (t pulseConditionAction) run(ctx context.Context) error {
+	_, settable, err := t.settable()
+
+	if err != nil {
+		return err
+	}
+
+	settable.Set(t.state)
+	defer settable.Set(!t.state)
+
+	return Wait(t.duration).run(ctx)
+}
@@ -0,0 +1,97 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+type conditionMap map[string]conditional.Condition
+
+func (m conditionMap) GetCondition(name string) conditional.Condition {
+	return m[name]
+}
+
+func TestSetConditionAction(t *testing.T) {
+	condition := conditional.NewManualCondition(false)
+	defer condition.Close()
+
+	getter := conditionMap{
+		"foo":   condition,
+		"never": conditional.NewCompositeCondition(conditional.OperatorAnd, condition),
+	}
+
+	if err := NewSetConditionAction(getter, "foo", true).run(context.Background()); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if state := conditional.StateOf(condition); state != conditional.StateTrue {
+		t.Errorf("expected %s, got %s", conditional.StateTrue, state)
+	}
+
+	if err := NewSetConditionAction(getter, "missing", true).run(context.Background()); err == nil {
+		t.Error("expected an error for a missing condition")
+	}
+
+	if err := NewSetConditionAction(getter, "never", true).run(context.Background()); err == nil {
+		t.Error("expected an error for an unsettable condition")
+	}
+}
+
+func TestToggleConditionAction(t *testing.T) {
+	condition := conditional.NewManualCondition(false)
+	defer condition.Close()
+
+	action := NewToggleConditionAction(conditionMap{"foo": condition}, "foo")
+
+	for _, expected := range []conditional.State{conditional.StateTrue, conditional.StateFalse, conditional.StateTrue} {
+		if err := action.run(context.Background()); err != nil {
+			t.Errorf("expected no error but got: %s", err)
+		}
+
+		if state := conditional.StateOf(condition); state != expected {
+			t.Errorf("expected %s, got %s", expected, state)
+		}
+	}
+
+	condition.SetUnknown()
+	action.run(context.Background())
+
+	if state := conditional.StateOf(condition); state != conditional.StateTrue {
+		t.Errorf("expected %s, got %s", conditional.StateTrue, state)
+	}
+}
+
+func TestPulseConditionAction(t *testing.T) {
+	condition := conditional.NewManualCondition(false)
+	defer condition.Close()
+
+	ch := make(chan bool, 4)
+	defer condition.Register(conditional.NewChannelObserver(ch))()
+	<-ch
+
+	action := NewPulseConditionAction(conditionMap{"foo": condition}, "foo", true, time.Millisecond)
+
+	if err := action.run(context.Background()); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if !<-ch || <-ch {
+		t.Error("expected the condition to be set and then unset")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	action = NewPulseConditionAction(conditionMap{"foo": condition}, "foo", true, time.Hour)
+
+	if err := action.run(ctx); err != context.Canceled {
+		t.Errorf("expected a cancellation but got: %v", err)
+	}
+
+	if state := conditional.StateOf(condition); state != conditional.StateFalse {
+		t.Errorf("expected %s, got %s", conditional.StateFalse, state)
+	}
+}
@@ -0,0 +1,89 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ErrorPolicy defines what a watch does when an action fails.
+type ErrorPolicy int
+
+const (
+	// ErrorFail stops the watch, which returns the action error.
+	ErrorFail ErrorPolicy = iota
+
+	// ErrorLog reports the action error and keeps on watching.
+	ErrorLog
+
+	// ErrorRetry retries the failed action as defined by the retry policy of
+	// the trigger, and reports the action error if all the attempts fail,
+	// before keeping on watching.
+	ErrorRetry
+
+	// ErrorDisable reports the action error and stops the watch, which
+	// doesn't return the error.
+	ErrorDisable
+)
+
+func (p ErrorPolicy) String() string {
+	switch p {
+	case ErrorLog:
+		return "log"
+	case ErrorRetry:
+		return "retry"
+	case ErrorDisable:
+		return "disable"
+	}
+
+	return "fail"
+}
+
+// DefaultErrorRetryDelay is the delay before the first retry of ErrorRetry
+// when none is specified.
+const DefaultErrorRetryDelay = time.Second
+
+// An ErrorHandler gets reported the action errors that don't stop a watch.
+type ErrorHandler func(err error)
+
+// WithErrorHandler injects an error handler in the specified context.
+//
+// Watches report the action errors that don't stop them to that handler.
+// Without one, the errors get logged with the standard logger.
+func WithErrorHandler(ctx context.Context, handler ErrorHandler) context.Context {
+	return context.WithValue(ctx, errorHandlerKey, handler)
+}
+
+// GetErrorHandler gets the error handler from a context.
+func GetErrorHandler(ctx context.Context) ErrorHandler {
+	handler, _ := ctx.Value(errorHandlerKey).(ErrorHandler)
+
+	return handler
+}
+
+func reportError(ctx context.Context, err error) {
+	if name := GetConditionName(ctx); name != nil {
+		err = fmt.Errorf("condition \"%s\": %s", *name, err)
+	}
+
+	if handler := GetErrorHandler(ctx); handler != nil {
+		handler(err)
+	} else {
+		log.Print(err)
+	}
+}
+
+func (t Trigger) runAction(ctx context.Context, action Action) error {
+	if t.OnError == ErrorRetry {
+		policy := t.Retry
+
+		if policy.Initial <= 0 {
+			policy.Initial = DefaultErrorRetryDelay
+		}
+
+		action = RetryWithPolicy(action, policy)
+	}
+
+	return action.run(ctx)
+}
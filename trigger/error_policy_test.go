@@ -0,0 +1,114 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func TestWatchErrorPolicy(t *testing.T) {
+	testCases := []struct {
+		Policy   ErrorPolicy
+		Calls    int
+		Reported int
+		Fails    bool
+	}{
+		{ErrorFail, 1, 0, true},
+		{ErrorLog, 2, 2, false},
+		{ErrorRetry, 6, 2, false},
+		{ErrorDisable, 1, 1, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Policy.String(), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var reported []error
+
+			ctx = WithConditionName(ctx, "foo")
+			ctx = WithErrorHandler(ctx, func(err error) { reported = append(reported, err) })
+
+			condition := conditional.NewManualCondition(false)
+			defer condition.Close()
+
+			calls := 0
+			action := FuncAction(func(context.Context) error {
+				calls++
+				return errors.New("fail")
+			})
+
+			done := make(chan error)
+			go func() {
+				done <- Watch(ctx, condition, Trigger{
+					Up:      action,
+					Down:    action,
+					OnError: testCase.Policy,
+					Retry:   RetryPolicy{Max: 3, Initial: time.Millisecond},
+				})
+			}()
+
+			time.Sleep(50 * time.Millisecond)
+			condition.Set(true)
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+
+			err := <-done
+
+			if testCase.Fails && err == nil {
+				t.Error("expected an error")
+			} else if !testCase.Fails && err != nil {
+				t.Errorf("expected no error but got: %s", err)
+			}
+
+			if calls != testCase.Calls {
+				t.Errorf("expected %d calls, got %d", testCase.Calls, calls)
+			}
+
+			if len(reported) != testCase.Reported {
+				t.Errorf("expected %d reported errors, got %v", testCase.Reported, reported)
+			}
+
+			if len(reported) > 0 && !strings.HasPrefix(reported[0].Error(), "condition \"foo\": trigger down: fail") {
+				t.Errorf("unexpected reported error: %s", reported[0])
+			}
+		})
+	}
+}
+
+func TestRunActionRetry(t *testing.T) {
+	calls := 0
+	action := FuncAction(func(context.Context) error {
+		if calls++; calls < 3 {
+			return errors.New("fail")
+		}
+
+		return nil
+	})
+
+	tr := Trigger{OnError: ErrorRetry, Retry: RetryPolicy{Initial: time.Millisecond}}
+
+	if err := tr.runAction(context.Background(), action); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+
+	if err := tr.runAction(ctx, action); err == nil {
+		t.Error("expected an error")
+	}
+
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+}
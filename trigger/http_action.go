@@ -0,0 +1,123 @@
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+type httpAction struct {
+	method      string
+	url         string
+	urlTemplate *template.Template
+	header      http.Header
+	body        *template.Template
+	statusCodes []int
+	client      *http.Client
+}
+
+// NewHTTPAction instantiates a new action that sends a HTTP request.
+//
+// If a body template is specified, it is executed with a TemplateData to
+// generate the request body.
+//
+// The action fails if the request cannot be sent within the specified
+// timeout or if the response status code is not one of the specified ones.
+// If no status codes are specified, any 2xx status code is accepted.
+func NewHTTPAction(method string, url string, header http.Header, body *template.Template, statusCodes []int, timeout time.Duration) Action {
+	return &httpAction{
+		method:      method,
+		url:         url,
+		header:      header,
+		body:        body,
+		statusCodes: statusCodes,
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+// NewHTTPTemplateAction instantiates a new action that sends a HTTP request
+// to an URL generated from a template.
+//
+// The URL template is executed with a TemplateData whenever the action runs.
+// It otherwise behaves like NewHTTPAction.
+func NewHTTPTemplateAction(method string, url *template.Template, header http.Header, body *template.Template, statusCodes []int, timeout time.Duration) Action {
+	return &httpAction{
+		method:      method,
+		urlTemplate: url,
+		header:      header,
+		body:        body,
+		statusCodes: statusCodes,
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+func (t *httpAction) run(ctx context.Context) error {
+	url := t.url
+
+	if t.urlTemplate != nil {
+		data, err := executeTemplate(ctx, t.urlTemplate)
+
+		if err != nil {
+			return fmt.Errorf("generating the URL of \"%s\": %s", t.method, err)
+		}
+
+		url = string(data)
+	}
+
+	var body io.Reader
+
+	if t.body != nil {
+		data, err := executeTemplate(ctx, t.body)
+
+		if err != nil {
+			return fmt.Errorf("generating the body of \"%s %s\": %s", t.method, url, err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(t.method, url, body)
+
+	if err != nil {
+		return err
+	}
+
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+
+	resp, err := t.client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if !t.expected(resp.StatusCode) {
+		output, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("sending \"%s %s\": unexpected status %s\nResponse was:\n%s", t.method, url, resp.Status, output)
+	}
+
+	return nil
+}
+
+func (t *httpAction) expected(statusCode int) bool {
+	if len(t.statusCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+
+	for _, code := range t.statusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
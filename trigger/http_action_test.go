@@ -0,0 +1,111 @@
+package trigger
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPAction(t *testing.T) {
+	var body string
+	var contentType string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		body = string(data)
+		contentType = req.Header.Get("Content-Type")
+
+		if req.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer ts.Close()
+
+	tmpl, err := NewTemplate("body", `{"text": {{json .Name}}, "up": {{.State}}}`)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	ctx := context.Background()
+	ctx = WithConditionName(ctx, "foo \"bar\"")
+	ctx = WithConditionState(ctx, true)
+
+	action := NewHTTPAction("POST", ts.URL, header, tmpl, nil, time.Second)
+
+	if err = action.run(ctx); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if expected := `{"text": "foo \"bar\"", "up": true}`; body != expected {
+		t.Errorf("expected %q as the body, got %q", expected, body)
+	}
+
+	if contentType != "application/json" {
+		t.Errorf("expected a JSON content type, got %q", contentType)
+	}
+
+	action = NewHTTPAction("POST", ts.URL, nil, nil, []int{200}, time.Second)
+
+	if err = action.run(ctx); err == nil {
+		t.Error("expected an error for an unexpected status code")
+	}
+
+	action = NewHTTPAction("GET", ts.URL+"/fail", nil, nil, nil, time.Second)
+
+	if err = action.run(ctx); err == nil {
+		t.Error("expected an error for a failing status code")
+	}
+
+	action = NewHTTPAction("GET", "http://localhost:0", nil, nil, nil, time.Second)
+
+	if err = action.run(ctx); err == nil {
+		t.Error("expected an error for an unreachable server")
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	if _, err := NewTemplate("body", "{{.Missing"); err == nil {
+		t.Error("expected an error")
+	}
+
+	tmpl, _ := NewTemplate("body", "{{.Missing}}")
+	action := NewHTTPAction("GET", "http://localhost:0", nil, tmpl, nil, time.Second)
+
+	if err := action.run(context.Background()); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestHTTPTemplateAction(t *testing.T) {
+	var path string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+	}))
+	defer ts.Close()
+
+	url, _ := NewTemplate("url", ts.URL+"/{{.Name}}/{{if .State}}on{{else}}off{{end}}")
+	ctx := WithConditionName(context.Background(), "light")
+	ctx = WithConditionState(ctx, false)
+
+	if err := NewHTTPTemplateAction("POST", url, nil, nil, nil, time.Second).run(ctx); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+
+	if path != "/light/off" {
+		t.Errorf("expected %q, got %q", "/light/off", path)
+	}
+
+	url, _ = NewTemplate("url", "{{.Missing}}")
+
+	if err := NewHTTPTemplateAction("POST", url, nil, nil, nil, time.Second).run(ctx); err == nil {
+		t.Error("expected an error")
+	}
+}
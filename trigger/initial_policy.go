@@ -0,0 +1,77 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+)
+
+// InitialPolicy defines whether a watch acts upon the initial state of the
+// condition.
+type InitialPolicy int
+
+const (
+	// InitialFire runs the action of the initial state.
+	InitialFire InitialPolicy = iota
+
+	// InitialSkip ignores the initial state: only the subsequent state
+	// changes run actions.
+	InitialSkip
+
+	// InitialChanged only runs the action of the initial state if it differs
+	// from the last state stored for the condition in the state store of the
+	// context. Without a state store, a condition name or a stored state, the
+	// action runs.
+	InitialChanged
+)
+
+func (p InitialPolicy) String() string {
+	switch p {
+	case InitialSkip:
+		return "skip"
+	case InitialChanged:
+		return "changed"
+	}
+
+	return "fire"
+}
+
+// skipInitial tells whether the action of the initial state must be skipped.
+func (t Trigger) skipInitial(ctx context.Context, state bool) bool {
+	switch t.Initial {
+	case InitialSkip:
+		return true
+	case InitialChanged:
+		store := GetStateStore(ctx)
+		name := GetConditionName(ctx)
+
+		if store == nil || name == nil {
+			return false
+		}
+
+		stored, ok, err := store.GetState(*name)
+
+		if err != nil {
+			reportError(ctx, fmt.Errorf("reading the stored state: %s", err))
+			return false
+		}
+
+		return ok && stored == state
+	}
+
+	return false
+}
+
+// storeState stores the state the trigger acted upon, if the context has a
+// state store and a condition name.
+func storeState(ctx context.Context, state bool) {
+	store := GetStateStore(ctx)
+	name := GetConditionName(ctx)
+
+	if store == nil || name == nil {
+		return
+	}
+
+	if err := store.SetState(*name, state); err != nil {
+		reportError(ctx, fmt.Errorf("storing the state: %s", err))
+	}
+}
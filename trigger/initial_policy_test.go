@@ -0,0 +1,100 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func TestWatchInitialPolicy(t *testing.T) {
+	up, down := true, false
+	testCases := []struct {
+		Policy   InitialPolicy
+		Stored   *bool
+		Expected []bool
+	}{
+		{InitialFire, nil, []bool{true, false}},
+		{InitialSkip, nil, []bool{false}},
+		{InitialChanged, nil, []bool{true, false}},
+		{InitialChanged, &down, []bool{true, false}},
+		{InitialChanged, &up, []bool{false}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Policy.String(), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			store := NewMemoryStateStore()
+
+			if testCase.Stored != nil {
+				store.SetState("foo", *testCase.Stored)
+			}
+
+			ctx = WithConditionName(ctx, "foo")
+			ctx = WithStateStore(ctx, store)
+
+			condition := conditional.NewManualCondition(true)
+			defer condition.Close()
+
+			calls := make(chan bool, 10)
+			action := FuncAction(func(ctx context.Context) error {
+				calls <- *GetConditionState(ctx)
+				return nil
+			})
+
+			done := make(chan error)
+			go func() {
+				done <- Watch(ctx, condition, Trigger{
+					Up:      action,
+					Down:    action,
+					Initial: testCase.Policy,
+				})
+			}()
+
+			time.Sleep(20 * time.Millisecond)
+			condition.Set(false)
+
+			for _, expected := range testCase.Expected {
+				if state := <-calls; state != expected {
+					t.Errorf("expected %v, got %v", expected, state)
+				}
+			}
+
+			cancel()
+			<-done
+
+			if count := len(calls); count != 0 {
+				t.Errorf("expected no more calls, got %d", count)
+			}
+
+			if state, ok, _ := store.GetState("foo"); !ok || state {
+				t.Errorf("expected the last state to be stored, got %t (%t)", state, ok)
+			}
+		})
+	}
+}
+
+func TestWatchInitialChangedWithoutStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	condition := conditional.NewManualCondition(true)
+	defer condition.Close()
+
+	calls := make(chan bool, 1)
+	action := FuncAction(func(ctx context.Context) error {
+		calls <- *GetConditionState(ctx)
+		return nil
+	})
+
+	go Watch(ctx, condition, Trigger{Up: action, Initial: InitialChanged})
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Error("expected the initial state to fire without a state store")
+	}
+}
@@ -2,13 +2,110 @@ package trigger
 
 import (
 	"context"
+	"math/rand"
 	"time"
 )
 
+const (
+	// DefaultRetryMax is the maximum number of attempts of a RetryPolicy
+	// when none is specified.
+	DefaultRetryMax = 3
+
+	// DefaultRetryMultiplier is the factor by which the delay of a
+	// RetryPolicy grows after every attempt when none is specified.
+	DefaultRetryMultiplier = 2
+)
+
+// RetryPolicy defines how an action gets retried upon failure.
+type RetryPolicy struct {
+	// Max is the maximum number of attempts, including the first one.
+	// Defaults to DefaultRetryMax.
+	Max int
+
+	// Initial is the delay between the first and the second attempt.
+	Initial time.Duration
+
+	// MaxDelay caps the delay between two attempts. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Multiplier is the factor by which the delay grows after every attempt.
+	// Defaults to DefaultRetryMultiplier. A multiplier of 1 gives a constant
+	// delay.
+	Multiplier float64
+
+	// Jitter randomizes the delays, as a fraction of them: every delay is
+	// randomly chosen within [delay * (1 - jitter), delay * (1 + jitter)].
+	Jitter float64
+
+	// Deadline bounds the total time spent retrying: no attempt gets started
+	// after it elapses, counting from the first attempt. Zero means no
+	// deadline.
+	Deadline time.Duration
+
+	// Retryable tells whether an error is worth retrying. By default, all
+	// errors are retryable, except permanent ones and context errors.
+	Retryable func(err error) bool
+}
+
+type permanentError struct {
+	error
+}
+
+// Permanent marks an error as not worth retrying.
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+// IsPermanent tells whether an error was marked as not worth retrying.
+func IsPermanent(err error) bool {
+	_, ok := err.(permanentError)
+
+	return ok
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if IsPermanent(err) || err == context.Canceled || err == context.DeadlineExceeded {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+
+	return true
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+
+	if multiplier <= 0 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	delay := float64(p.Initial)
+
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+
+	return time.Duration(delay)
+}
+
 type retryAction struct {
 	Action
-	max   int
-	delay time.Duration
+	policy RetryPolicy
 }
 
 // Retry returns an action that, upon failure, retries the specified number of
@@ -17,20 +114,56 @@ type retryAction struct {
 // If max is 0 or less, the action gets never called, and thus never fails.
 // This is kind-of useless.
 func Retry(action Action, max int, delay time.Duration) Action {
+	if max <= 0 {
+		return FuncAction(func(context.Context) error { return nil })
+	}
+
+	return RetryWithPolicy(action, RetryPolicy{
+		Max:        max,
+		Initial:    delay,
+		Multiplier: 1,
+	})
+}
+
+// RetryWithPolicy returns an action that, upon failure, retries as defined by
+// the specified policy.
+//
+// Waits between attempts are interrupted when the context expires, in which
+// case the last error of the action is returned.
+func RetryWithPolicy(action Action, policy RetryPolicy) Action {
 	return retryAction{
 		Action: action,
-		max:    max,
-		delay:  delay,
+		policy: policy,
 	}
 }
 
 func (t retryAction) run(ctx context.Context) (err error) {
-	for i := 0; i < t.max; i++ {
-		if err = t.Action.run(ctx); err == nil {
-			return
+	max := t.policy.Max
+
+	if max <= 0 {
+		max = DefaultRetryMax
+	}
+
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		if err = t.Action.run(ctx); err == nil || attempt >= max || !t.policy.retryable(err) {
+			break
 		}
 
-		time.Sleep(t.delay)
+		delay := t.policy.delay(attempt)
+
+		if t.policy.Deadline > 0 && time.Since(start)+delay > t.policy.Deadline {
+			break
+		}
+
+		if Wait(delay).run(ctx) != nil {
+			break
+		}
+	}
+
+	if perr, ok := err.(permanentError); ok {
+		err = perr.error
 	}
 
 	return
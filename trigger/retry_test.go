@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestRetry(t *testing.T) {
@@ -39,3 +40,129 @@ func TestRetryFailure(t *testing.T) {
 		t.Error("expected an error but didn't get one")
 	}
 }
+
+func TestRetryNever(t *testing.T) {
+	action := Retry(FuncAction(func(ctx context.Context) error {
+		t.Error("expected the action not to be called")
+		return nil
+	}), 0, 0)
+
+	if err := action.run(context.Background()); err != nil {
+		t.Errorf("expected no error but got: %s", err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{
+		Initial:  time.Second,
+		MaxDelay: 5 * time.Second,
+	}
+
+	for i, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if delay := policy.delay(i + 1); delay != expected {
+			t.Errorf("expected a delay of %s for attempt %d, got %s", expected, i+1, delay)
+		}
+	}
+
+	policy = RetryPolicy{Initial: time.Second, Multiplier: 3, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		if delay := policy.delay(2); delay < 1500*time.Millisecond || delay > 4500*time.Millisecond {
+			t.Fatalf("expected a delay within [1.5s, 4.5s], got %s", delay)
+		}
+	}
+}
+
+func TestRetryWithPolicy(t *testing.T) {
+	fail := errors.New("fail")
+	calls := 0
+	action := FuncAction(func(ctx context.Context) error {
+		calls++
+		return fail
+	})
+
+	if err := RetryWithPolicy(action, RetryPolicy{Max: 4}).run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+
+	calls = 0
+	policy := RetryPolicy{
+		Max:       4,
+		Retryable: func(err error) bool { return err != fail },
+	}
+
+	if err := RetryWithPolicy(action, policy).run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected a single call for a non-retryable error, got %d", calls)
+	}
+}
+
+func TestRetryWithPolicyPermanent(t *testing.T) {
+	fail := errors.New("fail")
+	calls := 0
+	action := FuncAction(func(ctx context.Context) error {
+		calls++
+		return Permanent(fail)
+	})
+
+	if err := RetryWithPolicy(action, RetryPolicy{Max: 4}).run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected a single call for a permanent error, got %d", calls)
+	}
+
+	if IsPermanent(fail) || !IsPermanent(Permanent(fail)) {
+		t.Error("expected only marked errors to be permanent")
+	}
+}
+
+func TestRetryWithPolicyDeadline(t *testing.T) {
+	calls := 0
+	action := FuncAction(func(ctx context.Context) error {
+		calls++
+		return errors.New("fail")
+	})
+	policy := RetryPolicy{
+		Max:      10,
+		Initial:  20 * time.Millisecond,
+		Deadline: 50 * time.Millisecond,
+	}
+
+	if err := RetryWithPolicy(action, policy).run(context.Background()); err == nil {
+		t.Error("expected an error but didn't get one")
+	}
+
+	// Attempts start at 0ms and 20ms, and the next one would be at 60ms.
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryWithPolicyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	action := FuncAction(func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errors.New("fail")
+	})
+
+	start := time.Now()
+
+	if err := RetryWithPolicy(action, RetryPolicy{Max: 3, Initial: time.Hour}).run(ctx); err == nil {
+		t.Error("expected an error but didn't get one")
+	}
+
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Errorf("expected the retries to stop with the context, got %d calls", calls)
+	}
+}
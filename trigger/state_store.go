@@ -0,0 +1,166 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// A StateStore persists the last states that triggers acted upon, by
+// condition name.
+type StateStore interface {
+	// GetState returns the last state stored for the specified name, if
+	// there is one.
+	GetState(name string) (state bool, ok bool, err error)
+
+	// SetState stores the state for the specified name.
+	SetState(name string, state bool) error
+}
+
+// WithStateStore injects a state store in the specified context.
+//
+// Watches store the states of named conditions whose actions succeeded in it.
+func WithStateStore(ctx context.Context, store StateStore) context.Context {
+	return context.WithValue(ctx, stateStoreKey, store)
+}
+
+// GetStateStore gets the state store from a context.
+func GetStateStore(ctx context.Context) StateStore {
+	store, _ := ctx.Value(stateStoreKey).(StateStore)
+
+	return store
+}
+
+// MemoryStateStore is a StateStore that keeps the states in memory.
+type MemoryStateStore struct {
+	lock   sync.Mutex
+	states map[string]bool
+}
+
+// NewMemoryStateStore instantiates a new empty in-memory state store.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		states: make(map[string]bool),
+	}
+}
+
+// GetState returns the last state stored for the specified name, if there is
+// one.
+func (s *MemoryStateStore) GetState(name string) (bool, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, ok := s.states[name]
+
+	return state, ok, nil
+}
+
+// SetState stores the state for the specified name.
+func (s *MemoryStateStore) SetState(name string, state bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.states[name] = state
+
+	return nil
+}
+
+// FileStateStore is a StateStore that keeps the states in a JSON file, so
+// that they survive restarts.
+type FileStateStore struct {
+	path   string
+	lock   sync.Mutex
+	states map[string]bool
+}
+
+// NewFileStateStore instantiates a new state store that reads and writes the
+// states to the specified file.
+//
+// The file doesn't have to exist, but must be valid if it does.
+func NewFileStateStore(path string) (*FileStateStore, error) {
+	store := &FileStateStore{
+		path:   path,
+		states: make(map[string]bool),
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &store.states); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// GetState returns the last state stored for the specified name, if there is
+// one.
+func (s *FileStateStore) GetState(name string) (bool, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, ok := s.states[name]
+
+	return state, ok, nil
+}
+
+// SetState stores the state for the specified name, and writes all the
+// states to the file.
+//
+// The file is replaced atomically, so that a crash never leaves it partially
+// written. If the file cannot be written, the state is not stored.
+func (s *FileStateStore) SetState(name string, state bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if current, ok := s.states[name]; ok && current == state {
+		return nil
+	}
+
+	states := make(map[string]bool, len(s.states)+1)
+
+	for key, value := range s.states {
+		states[key] = value
+	}
+
+	states[name] = state
+
+	data, err := json.Marshal(states)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path))
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(f.Name(), s.path); err != nil {
+		return err
+	}
+
+	s.states = states
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package trigger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMemoryStateStore(t *testing.T) {
+	store := NewMemoryStateStore()
+
+	if _, ok, _ := store.GetState("foo"); ok {
+		t.Error("expected no state")
+	}
+
+	store.SetState("foo", true)
+
+	if state, ok, _ := store.GetState("foo"); !ok || !state {
+		t.Errorf("expected a true state, got %t (%t)", state, ok)
+	}
+}
+
+func TestFileStateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.json")
+	store, err := NewFileStateStore(path)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if _, ok, _ := store.GetState("foo"); ok {
+		t.Error("expected no state")
+	}
+
+	if err = store.SetState("foo", true); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if err = store.SetState("bar", false); err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	store, err = NewFileStateStore(path)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if state, ok, _ := store.GetState("foo"); !ok || !state {
+		t.Errorf("expected a persisted true state, got %t (%t)", state, ok)
+	}
+
+	if state, ok, _ := store.GetState("bar"); !ok || state {
+		t.Errorf("expected a persisted false state, got %t (%t)", state, ok)
+	}
+
+	if entries, _ := ioutil.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected no temporary file to remain, got %d files", len(entries))
+	}
+
+	ioutil.WriteFile(path, []byte("{"), 0644)
+
+	if _, err = NewFileStateStore(path); err == nil {
+		t.Error("expected an error for an invalid file")
+	}
+}
+
+func TestFileStateStoreWriteFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotomatic")
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileStateStore(filepath.Join(dir, "missing", "state.json"))
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if err = store.SetState("foo", true); err == nil {
+		t.Error("expected an error")
+	}
+
+	if _, ok, _ := store.GetState("foo"); ok {
+		t.Error("expected no state after a failed write")
+	}
+}
@@ -0,0 +1,130 @@
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+// TemplateData is the data that action templates are executed with.
+type TemplateData struct {
+	// Name is the name of the condition whose state changed, if it has one.
+	Name string
+
+	// State is the new state of the condition.
+	State bool
+
+	// Time is the time at which the condition state changed.
+	Time time.Time
+
+	// PreviousDuration is how long the condition stayed in its previous
+	// state. It is zero for the first state of a watched condition.
+	PreviousDuration time.Duration
+
+	// Sequence is the number of the state change, starting at 1 for the
+	// first state of a watched condition.
+	Sequence uint64
+
+	// ConfigurationFile is the path of the configuration file that declared
+	// the trigger, if any.
+	ConfigurationFile string
+
+	getter ConditionGetter
+}
+
+// Condition returns the state of the named condition, as `true`, `false` or
+// `unknown`.
+//
+// Templates can call it to access the states of the other conditions of the
+// configuration, like `{{if eq (.Condition "door") "true"}}open{{end}}`.
+func (d TemplateData) Condition(name string) (string, error) {
+	var condition conditional.Condition
+
+	if d.getter != nil {
+		condition = d.getter.GetCondition(name)
+	}
+
+	if condition == nil {
+		return "", fmt.Errorf("no condition found with the name \"%s\"", name)
+	}
+
+	return conditional.StateOf(condition).String(), nil
+}
+
+// NewTemplate parses a template for use by actions.
+//
+// In addition to the standard functions, templates can use `json` to
+// format a value as JSON, like `{"text": {{json .Name}}}`.
+func NewTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(template.FuncMap{
+		"json": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+
+			return string(data), err
+		},
+	}).Parse(text)
+}
+
+func newTemplateData(ctx context.Context) TemplateData {
+	data := TemplateData{
+		Time:   time.Now(),
+		getter: GetConditionGetter(ctx),
+	}
+
+	if name := GetConditionName(ctx); name != nil {
+		data.Name = *name
+	}
+
+	if state := GetConditionState(ctx); state != nil {
+		data.State = *state
+	}
+
+	if t := GetTransitionTime(ctx); t != nil {
+		data.Time = *t
+	}
+
+	if duration := GetPreviousStateDuration(ctx); duration != nil {
+		data.PreviousDuration = *duration
+	}
+
+	if sequence := GetSequenceNumber(ctx); sequence != nil {
+		data.Sequence = *sequence
+	}
+
+	if path := GetConfigurationFile(ctx); path != nil {
+		data.ConfigurationFile = *path
+	}
+
+	return data
+}
+
+func executeTemplate(ctx context.Context, tmpl *template.Template) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+
+	if err := tmpl.Execute(buffer, newTemplateData(ctx)); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func executeTemplates(ctx context.Context, tmpls []*template.Template) ([]string, error) {
+	result := make([]string, len(tmpls))
+
+	for i, tmpl := range tmpls {
+		data, err := executeTemplate(ctx, tmpl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = string(data)
+	}
+
+	return result, nil
+}
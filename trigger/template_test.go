@@ -0,0 +1,56 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func TestNewTemplateData(t *testing.T) {
+	door := conditional.NewManualCondition(true)
+	defer door.Close()
+
+	now := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := context.Background()
+	ctx = WithConditionName(ctx, "light")
+	ctx = WithConditionState(ctx, true)
+	ctx = WithTransitionTime(ctx, now)
+	ctx = WithPreviousStateDuration(ctx, time.Minute)
+	ctx = WithSequenceNumber(ctx, 3)
+	ctx = WithConfigurationFile(ctx, "home.yaml")
+	ctx = WithConditionGetter(ctx, conditionMap{"door": door})
+
+	tmpl, err := NewTemplate("test", `{{.Name}} {{.State}} {{.Time.Format "15:04"}} {{.PreviousDuration}} {{.Sequence}} {{.ConfigurationFile}} {{.Condition "door"}}`)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	data, err := executeTemplate(ctx, tmpl)
+
+	if err != nil {
+		t.Fatalf("expected no error but got: %s", err)
+	}
+
+	if expected := "light true 03:04 1m0s 3 home.yaml true"; string(data) != expected {
+		t.Errorf("expected %q, got %q", expected, data)
+	}
+
+	door.SetUnknown()
+
+	if data, _ = executeTemplate(ctx, tmpl); string(data) != "light true 03:04 1m0s 3 home.yaml unknown" {
+		t.Errorf("expected an unknown door, got %q", data)
+	}
+
+	tmpl, _ = NewTemplate("test", `{{.Condition "window"}}`)
+
+	if _, err = executeTemplate(ctx, tmpl); err == nil {
+		t.Error("expected an error for a missing condition")
+	}
+
+	if _, err = executeTemplate(context.Background(), tmpl); err == nil {
+		t.Error("expected an error without a condition getter")
+	}
+}
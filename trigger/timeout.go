@@ -0,0 +1,71 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is returned by actions that time out.
+type TimeoutError struct {
+	// Timeout is the duration after which the action timed out.
+	Timeout time.Duration
+
+	// Err is the error the action returned when it timed out.
+	Err error
+}
+
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s: %s", e.Timeout, e.Err)
+}
+
+// IsTimeout tells whether an error is a TimeoutError.
+func IsTimeout(err error) bool {
+	_, ok := err.(TimeoutError)
+
+	return ok
+}
+
+type timeoutAction struct {
+	Action
+	timeout time.Duration
+}
+
+// Timeout returns an action whose context expires after the specified
+// timeout.
+//
+// If the action fails after its context expired because of the timeout, the
+// returned action fails with a TimeoutError.
+//
+// A top-level action with a timeout, retried or not, is not subject to the
+// default timeout of its trigger.
+func Timeout(action Action, timeout time.Duration) Action {
+	return timeoutAction{
+		Action:  action,
+		timeout: timeout,
+	}
+}
+
+func (t timeoutAction) run(ctx context.Context) error {
+	actionCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	err := t.Action.run(actionCtx)
+
+	if err != nil && actionCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return TimeoutError{Timeout: t.timeout, Err: err}
+	}
+
+	return err
+}
+
+func hasTimeout(action Action) bool {
+	switch action := action.(type) {
+	case timeoutAction:
+		return true
+	case retryAction:
+		return hasTimeout(action.Action)
+	}
+
+	return false
+}
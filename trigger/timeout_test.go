@@ -0,0 +1,109 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func blockingAction() Action {
+	return FuncAction(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	err := Timeout(blockingAction(), 10*time.Millisecond).run(context.Background())
+
+	if !IsTimeout(err) {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+
+	if timeout := err.(TimeoutError).Timeout; timeout != 10*time.Millisecond {
+		t.Errorf("expected a timeout of 10ms, got %s", timeout)
+	}
+
+	fail := errors.New("fail")
+	action := FuncAction(func(ctx context.Context) error { return fail })
+
+	if err = Timeout(action, time.Second).run(context.Background()); err != fail {
+		t.Errorf("expected %s, got %v", fail, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err = Timeout(blockingAction(), time.Second).run(ctx); err != context.Canceled {
+		t.Errorf("expected a cancellation, got %v", err)
+	}
+}
+
+func TestTimeoutCommandGrandchild(t *testing.T) {
+	// The sleep process keeps the output of the shell open after the shell
+	// gets killed.
+	action := Timeout(NewCommandAction("sh", []string{"-c", "sleep 3; true"}, os.Environ()), 200*time.Millisecond)
+	start := time.Now()
+
+	if err := action.run(context.Background()); !IsTimeout(err) {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the action to return shortly after its timeout, but it took %s", elapsed)
+	}
+}
+
+func TestHasTimeout(t *testing.T) {
+	testCases := []struct {
+		Action   Action
+		Expected bool
+	}{
+		{blockingAction(), false},
+		{Timeout(blockingAction(), time.Second), true},
+		{Retry(Timeout(blockingAction(), time.Second), 2, 0), true},
+		{Retry(blockingAction(), 2, 0), false},
+		{Sequence(Timeout(blockingAction(), time.Second)), false},
+	}
+
+	for i, testCase := range testCases {
+		if value := hasTimeout(testCase.Action); value != testCase.Expected {
+			t.Errorf("expected %t for action %d, got %t", testCase.Expected, i, value)
+		}
+	}
+}
+
+func TestWatchTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	condition := conditional.NewManualCondition(false)
+	defer condition.Close()
+
+	err := Watch(ctx, condition, Trigger{
+		Down:    blockingAction(),
+		Timeout: 10 * time.Millisecond,
+	})
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if expected := "trigger down: timed out after 10ms: context deadline exceeded"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err)
+	}
+
+	start := time.Now()
+	err = Watch(ctx, condition, Trigger{
+		Down:    Timeout(blockingAction(), 50*time.Millisecond),
+		Timeout: 10 * time.Millisecond,
+	})
+
+	if err == nil || time.Since(start) < 50*time.Millisecond {
+		t.Errorf("expected the action timeout to override the trigger one, got %v", err)
+	}
+}
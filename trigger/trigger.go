@@ -4,6 +4,7 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/intelux/gotomatic/conditional"
 )
@@ -14,6 +15,32 @@ type Trigger struct {
 	Up Action
 	// Down is called whenever the watched condition becomes false.
 	Down Action
+
+	// Unknown defines how an unknown state of the watched condition is
+	// handled. With conditional.UnknownHold, which is the default, unknown
+	// states are ignored and no action gets called.
+	Unknown conditional.UnknownPolicy
+
+	// OnError defines what happens when an action fails. With ErrorFail,
+	// which is the default, the watch stops and returns the error.
+	OnError ErrorPolicy
+
+	// Retry defines how a failed action is retried with ErrorRetry. Its
+	// initial delay defaults to DefaultErrorRetryDelay.
+	Retry RetryPolicy
+
+	// Concurrency defines what happens to the state changes that occur while
+	// an action is running. With ConcurrencyQueue, which is the default, the
+	// actions of all the state changes run in order.
+	Concurrency ConcurrencyPolicy
+
+	// Timeout is the default timeout of the actions, that applies to the
+	// actions that don't have one of their own. Zero means no timeout.
+	Timeout time.Duration
+
+	// Initial defines whether the initial state of the watched condition runs
+	// an action. With InitialFire, which is the default, it does.
+	Initial InitialPolicy
 }
 
 // Watch a condition a drive a trigger with its states changes.
@@ -21,41 +48,157 @@ type Trigger struct {
 // The watch exits when the condition is closed, the trigger fails or the
 // context expires. The two first cases, return an error. The third one
 // doesn't.
+//
+// Whether a failed action fails the trigger depends on its error policy.
+// Action errors that don't are reported to the error handler of the context.
+//
+// An action is only called when the satisfied state differs from the one of
+// the previously called action.
+//
+// Actions run outside of the condition observer, one at a time, and the
+// concurrency policy of the trigger defines what happens to state changes
+// that occur while an action is running. The watch waits for the running
+// action to return before exiting.
 func Watch(ctx context.Context, condition conditional.Condition, trigger Trigger) (err error) {
-	stateCh := make(chan bool, 1)
-	defer close(stateCh)
-
-	unregister := condition.Register(conditional.NewChannelObserver(stateCh))
-	defer unregister()
+	stateCh := make(chan conditional.State, 1)
+	unregister := condition.Register(conditional.NewStateChannelObserver(stateCh))
 
 	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	d := newDispatcher(trigger)
+
+	// The observer must be unregistered before waiting for the running
+	// action, which may change the condition and block on the notification
+	// otherwise.
+	defer func() {
+		cancel()
+		unregisterDraining(unregister, stateCh)
+		d.wait()
+		close(stateCh)
+	}()
+
+	var last *bool
+	var since time.Time
+	var sequence uint64
 
 	for {
 		select {
-		case state := <-stateCh:
-			var action Action
-			var errPrefix string
-
-			if state {
-				action = trigger.Up
-				errPrefix = "trigger up"
-			} else {
-				action = trigger.Down
-				errPrefix = "trigger down"
+		case s := <-stateCh:
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !s.Known() && (trigger.Unknown == conditional.UnknownHold || trigger.Unknown == conditional.UnknownPropagate) {
+				continue
+			}
+
+			state := trigger.Unknown.Resolve(s, false)
+
+			if last != nil && *last == state {
+				continue
+			}
+
+			initial := last == nil
+			now := time.Now()
+			sequence++
+			actionCtx := WithTransitionTime(ctx, now)
+			actionCtx = WithSequenceNumber(actionCtx, sequence)
+
+			if last != nil {
+				actionCtx = WithPreviousStateDuration(actionCtx, now.Sub(since))
 			}
 
-			if action != nil {
-				ctx := WithConditionState(ctx, state)
+			last = &state
+			since = now
 
-				if err = action.run(ctx); err != nil {
-					err = fmt.Errorf("%s: %s", errPrefix, err)
-					cancel()
-					return
-				}
+			if initial && trigger.skipInitial(actionCtx, state) {
+				continue
 			}
+
+			d.dispatch(job{
+				ctx:   WithConditionState(actionCtx, state),
+				state: state,
+			})
+		case err = <-d.errors:
+			if err == errTriggerDisabled {
+				err = nil
+			}
+
+			return
 		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+// unregisterDraining unregisters an observer while discarding the states it
+// is notified meanwhile, so that a pending notification can't block the
+// unregistration.
+func unregisterDraining(unregister func(), stateCh <-chan conditional.State) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		unregister()
+	}()
+
+	for {
+		select {
+		case <-stateCh:
+		case <-done:
+			return
+		}
+	}
+}
+
+// execute runs the action of a job within the specified context, applying
+// the error policy of the trigger.
+//
+// It only returns an error if the watch must stop.
+func (t Trigger) execute(ctx context.Context, j job) error {
+	var action Action
+	var errPrefix string
+
+	if j.state {
+		action = t.Up
+		errPrefix = "trigger up"
+	} else {
+		action = t.Down
+		errPrefix = "trigger down"
+	}
+
+	if action == nil {
+		storeState(j.ctx, j.state)
+		return nil
+	}
+
+	if t.Timeout > 0 && !hasTimeout(action) {
+		action = Timeout(action, t.Timeout)
+	}
+
+	err := t.runAction(ctx, action)
+
+	if err == nil {
+		storeState(j.ctx, j.state)
+		return nil
+	}
+
+	// An action cancelled while the watch goes on was superseded by a more
+	// recent state change: this is not a failure.
+	if ctx.Err() != nil && j.ctx.Err() == nil {
+		return nil
+	}
+
+	err = fmt.Errorf("%s: %s", errPrefix, err)
+
+	switch t.OnError {
+	case ErrorLog, ErrorRetry:
+		reportError(j.ctx, err)
+	case ErrorDisable:
+		reportError(j.ctx, fmt.Errorf("%s (trigger disabled)", err))
+		return errTriggerDisabled
+	default:
+		return err
+	}
+
+	return nil
+}
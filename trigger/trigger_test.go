@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/intelux/gotomatic/conditional"
 )
@@ -42,3 +43,140 @@ func TestWatch(t *testing.T) {
 		t.Errorf("expected no error but got: %s", err)
 	}
 }
+
+func TestWatchUnknown(t *testing.T) {
+	testCases := []struct {
+		Policy   conditional.UnknownPolicy
+		Expected []bool
+	}{
+		{conditional.UnknownHold, []bool{false}},
+		{conditional.UnknownFalse, []bool{false, true, false}},
+		{conditional.UnknownTrue, []bool{false}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Policy.String(), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			condition := conditional.NewManualCondition(true)
+			calls := make(chan bool, 10)
+			action := FuncAction(func(ctx context.Context) error {
+				calls <- *GetConditionState(ctx)
+				return nil
+			})
+
+			trigger := Trigger{
+				Up:      action,
+				Down:    action,
+				Unknown: testCase.Policy,
+			}
+
+			done := make(chan error)
+			go func() { done <- Watch(ctx, condition, trigger) }()
+
+			<-calls
+			condition.SetUnknown()
+			condition.Set(true)
+			condition.Set(false)
+
+			for _, expected := range testCase.Expected {
+				if state := <-calls; state != expected {
+					t.Errorf("expected %v, got %v", expected, state)
+				}
+			}
+
+			cancel()
+			<-done
+
+			if count := len(calls); count != 0 {
+				t.Errorf("expected no more calls, got %d", count)
+			}
+		})
+	}
+}
+
+func TestWatchMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	condition := conditional.NewManualCondition(false)
+	defer condition.Close()
+
+	calls := make(chan context.Context, 2)
+	action := FuncAction(func(ctx context.Context) error {
+		calls <- ctx
+		return nil
+	})
+
+	go Watch(ctx, condition, Trigger{Up: action, Down: action})
+
+	first := <-calls
+
+	if GetTransitionTime(first) == nil {
+		t.Error("expected a transition time")
+	}
+
+	if sequence := GetSequenceNumber(first); sequence == nil || *sequence != 1 {
+		t.Errorf("expected a sequence number of 1, got %v", sequence)
+	}
+
+	if duration := GetPreviousStateDuration(first); duration != nil {
+		t.Errorf("expected no previous state duration, got %s", *duration)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	condition.Set(true)
+	second := <-calls
+
+	if duration := GetPreviousStateDuration(second); duration == nil || *duration < 10*time.Millisecond {
+		t.Errorf("expected a previous state duration of at least 10ms, got %v", duration)
+	}
+
+	if sequence := GetSequenceNumber(second); sequence == nil || *sequence != 2 {
+		t.Errorf("expected a sequence number of 2, got %v", sequence)
+	}
+
+	if !GetTransitionTime(second).After(*GetTransitionTime(first)) {
+		t.Error("expected the transition times to increase")
+	}
+}
+
+func TestWatchShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The condition is not closed, as it would block on its lock if the test
+	// failed.
+	condition := conditional.NewManualCondition(false)
+	started := make(chan struct{})
+
+	// The action pulses its own condition when the watch stops, like a
+	// cancelled pulse would.
+	up := FuncAction(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+
+		condition.Set(false)
+		condition.Set(true)
+		condition.Set(false)
+
+		return ctx.Err()
+	})
+
+	done := make(chan error)
+
+	go func() {
+		done <- Watch(ctx, condition, Trigger{Up: up, Down: FuncAction(func(context.Context) error { return nil })})
+	}()
+
+	condition.Set(true)
+	<-started
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the watch to stop")
+	}
+}